@@ -0,0 +1,8 @@
+data "hetznerdns_api_status" "status" {}
+
+check "rate_limit" {
+  assert {
+    condition     = data.hetznerdns_api_status.status.ratelimit_remaining > 100
+    error_message = "Only ${data.hetznerdns_api_status.status.ratelimit_remaining} API requests remain until the rate limit resets in ${data.hetznerdns_api_status.status.ratelimit_reset} seconds."
+  }
+}
@@ -53,4 +53,12 @@ resource "hetznerdns_record" "example_com_srv" {
   value   = "10 0 389 ldap.example.com."
   type    = "SRV"
   ttl     = 3600
+}
+
+# Reference the zone by its name instead of its ID
+resource "hetznerdns_record" "example_com_www" {
+  zone_name = "example.com"
+  name      = "www"
+  value     = "1.2.3.4"
+  type      = "A"
 }
\ No newline at end of file
@@ -0,0 +1,204 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redacted replaces secrets in the audit log.
+const redacted = "[REDACTED]"
+
+// AuditLog appends an entry for every request of the client which could change anything to a file
+// in the JSON lines format. It is safe for concurrent use.
+type AuditLog struct {
+	path string
+	now  func() time.Time
+
+	mu sync.Mutex
+}
+
+// AuditEntry is a line of the audit log.
+type AuditEntry struct {
+	Timestamp    time.Time `json:"timestamp"`
+	Method       string    `json:"method"`
+	Path         string    `json:"path"`
+	Status       int       `json:"status,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	ResourceType string    `json:"resource_type,omitempty"`
+	ResourceID   string    `json:"resource_id,omitempty"`
+	ZoneID       string    `json:"zone_id,omitempty"`
+	Zone         string    `json:"zone,omitempty"`
+	RecordName   string    `json:"record_name,omitempty"`
+	RecordType   string    `json:"record_type,omitempty"`
+	OldValue     string    `json:"old_value,omitempty"`
+	NewValue     string    `json:"new_value,omitempty"`
+}
+
+// AuditInfo describes the object a request is made for. The client takes everything else from the request.
+// Its zone ID is also added to the span of the request if the request doesn't contain one.
+type AuditInfo struct {
+	ResourceType string
+	ResourceID   string
+	ZoneID       string
+	Zone         string
+	RecordName   string
+	RecordType   string
+	OldValue     string
+}
+
+type auditInfoKey struct{}
+
+// WithAuditInfo returns a context which adds the given info to the audit log entries of requests made with it.
+func WithAuditInfo(ctx context.Context, info AuditInfo) context.Context {
+	return context.WithValue(ctx, auditInfoKey{}, info)
+}
+
+// auditBody covers the fields of all request bodies which are written to the audit log.
+type auditBody struct {
+	ZoneID  string `json:"zone_id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Value   string `json:"value"`
+	TTL     *int64 `json:"ttl"`
+	Address string `json:"address"`
+	Port    int64  `json:"port"`
+}
+
+// NewAuditLog returns an audit log appending to the file at the given path, which is created if it doesn't exist.
+func NewAuditLog(path string) (*AuditLog, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening audit log: %w", err)
+	}
+
+	if err = file.Close(); err != nil {
+		return nil, fmt.Errorf("closing audit log: %w", err)
+	}
+
+	return &AuditLog{path: path, now: time.Now}, nil
+}
+
+// SetAuditLog makes the client write all requests which could change anything to the given audit log.
+func (c *Client) SetAuditLog(auditLog *AuditLog) {
+	c.auditLog = auditLog
+}
+
+// audit writes the entry for a request, which got the given response or failed with the given error.
+// The ID of an object created by a POST request is taken from the response.
+func (c *Client) audit(ctx context.Context, method, path string, reqBody []byte, resp *http.Response, reqErr error) error {
+	info, _ := ctx.Value(auditInfoKey{}).(AuditInfo)
+
+	entry := AuditEntry{
+		Timestamp:    c.auditLog.now().UTC(),
+		Method:       method,
+		Path:         path,
+		ResourceType: info.ResourceType,
+		ResourceID:   info.ResourceID,
+		ZoneID:       info.ZoneID,
+		Zone:         info.Zone,
+		RecordName:   info.RecordName,
+		RecordType:   info.RecordType,
+		OldValue:     info.OldValue,
+	}
+
+	if reqErr != nil {
+		entry.Error = reqErr.Error()
+	}
+
+	if resp != nil {
+		entry.Status = resp.StatusCode
+
+		if method == http.MethodPost && entry.ResourceID == "" && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			entry.ResourceID = createdID(resp)
+		}
+	}
+
+	var body auditBody
+	if len(reqBody) > 0 && json.Unmarshal(reqBody, &body) == nil {
+		if body.ZoneID != "" {
+			entry.ZoneID = body.ZoneID
+		}
+
+		switch {
+		case strings.HasPrefix(path, "/api/v1/records"):
+			entry.RecordName = body.Name
+			entry.RecordType = body.Type
+			entry.NewValue = body.Value
+		case strings.HasPrefix(path, "/api/v1/zones"):
+			entry.Zone = body.Name
+
+			if body.TTL != nil {
+				entry.NewValue = strconv.FormatInt(*body.TTL, 10)
+			}
+		case strings.HasPrefix(path, "/api/v1/primary_servers"):
+			entry.NewValue = net.JoinHostPort(body.Address, strconv.FormatInt(body.Port, 10))
+		}
+	}
+
+	return c.auditLog.write(entry, c.apiToken)
+}
+
+// createdID returns the ID of the object in the response to a create request, e.g. {"record": {"id": "..."}},
+// or an empty string if there is none.
+func createdID(resp *http.Response) string {
+	body, err := bufferBody(resp)
+	if err != nil {
+		return ""
+	}
+
+	var created map[string]struct {
+		ID string `json:"id"`
+	}
+
+	if json.Unmarshal(body, &created) != nil || len(created) != 1 {
+		return ""
+	}
+
+	for _, object := range created {
+		return object.ID
+	}
+
+	return ""
+}
+
+// write appends the entry to the audit log with all occurrences of the secret redacted.
+func (l *AuditLog) write(entry AuditEntry, secret string) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("serializing audit log entry: %w", err)
+	}
+
+	if secret != "" {
+		line = []byte(strings.ReplaceAll(string(line), secret, redacted))
+	}
+
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening audit log: %w", err)
+	}
+
+	if _, err = file.Write(line); err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("writing audit log: %w", err)
+	}
+
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("closing audit log: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAuditLog(t *testing.T, path string) []AuditEntry {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = file.Close() })
+
+	var entries []AuditEntry
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry AuditEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry), scanner.Text())
+
+		entries = append(entries, entry)
+	}
+
+	require.NoError(t, scanner.Err())
+
+	return entries
+}
+
+func TestClientAuditLog(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, _, zone := createFakeClient(t)
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	auditLog, err := NewAuditLog(path)
+	require.NoError(t, err)
+
+	now := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	auditLog.now = func() time.Time { return now }
+
+	client.SetAuditLog(auditLog)
+
+	_, err = client.GetZone(ctx, zone.ID)
+	require.NoError(t, err)
+
+	record, err := client.CreateRecord(WithAuditInfo(ctx, AuditInfo{ResourceType: "hetznerdns_record"}), CreateRecordOpts{
+		ZoneID: zone.ID,
+		Type:   "TXT",
+		Name:   "www",
+		Value:  "token=" + fakeToken,
+	})
+	require.NoError(t, err)
+
+	err = client.DeleteRecord(WithAuditInfo(ctx, AuditInfo{
+		ResourceType: "hetznerdns_record",
+		ResourceID:   record.ID,
+		ZoneID:       zone.ID,
+		Zone:         zone.Name,
+		RecordName:   "www",
+		RecordType:   "TXT",
+		OldValue:     "token=" + fakeToken,
+	}), record.ID)
+	require.NoError(t, err)
+
+	// Reads are not audited, failed requests are.
+	_, err = client.UpdateZone(ctx, Zone{ID: "unknown", Name: "example.org", TTL: 60})
+	require.Error(t, err)
+
+	assert.Equal(t, []AuditEntry{
+		{
+			Timestamp:    now,
+			Method:       "POST",
+			Path:         "/api/v1/records",
+			Status:       200,
+			ResourceType: "hetznerdns_record",
+			ResourceID:   record.ID,
+			ZoneID:       zone.ID,
+			RecordName:   "www",
+			RecordType:   "TXT",
+			NewValue:     "token=" + redacted,
+		},
+		{
+			Timestamp:    now,
+			Method:       "DELETE",
+			Path:         "/api/v1/records/" + record.ID,
+			Status:       200,
+			ResourceType: "hetznerdns_record",
+			ResourceID:   record.ID,
+			ZoneID:       zone.ID,
+			Zone:         zone.Name,
+			RecordName:   "www",
+			RecordType:   "TXT",
+			OldValue:     "token=" + redacted,
+		},
+		{
+			Timestamp: now,
+			Method:    "PUT",
+			Path:      "/api/v1/zones/unknown",
+			Status:    404,
+			Zone:      "example.org",
+			NewValue:  "60",
+		},
+	}, readAuditLog(t, path))
+}
+
+func TestClientAuditLogConcurrent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, _, zone := createFakeClient(t)
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	auditLog, err := NewAuditLog(path)
+	require.NoError(t, err)
+
+	client.SetAuditLog(auditLog)
+
+	const count = 20
+
+	var wg sync.WaitGroup
+
+	for i := range count {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := client.CreateRecord(ctx, CreateRecordOpts{ZoneID: zone.ID, Type: "A", Name: "host" + strconv.Itoa(i), Value: "192.0.2.1"})
+			assert.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	entries := readAuditLog(t, path)
+	require.Len(t, entries, count)
+
+	for _, entry := range entries {
+		assert.Equal(t, "POST", entry.Method)
+		assert.Equal(t, "192.0.2.1", entry.NewValue)
+	}
+}
+
+func TestNewAuditLogInvalidPath(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewAuditLog(filepath.Join(t.TempDir(), "missing", "audit.jsonl"))
+	require.Error(t, err)
+}
@@ -11,6 +11,7 @@ import (
 	"sync"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // UnauthorizedError represents the message of an HTTP 401 response.
@@ -29,6 +30,7 @@ type ErrorMessage struct {
 var (
 	ErrNotFound    = errors.New("not found")
 	ErrRateLimited = errors.New("rate limit exceeded")
+	ErrReadOnly    = errors.New("the client is read-only")
 )
 
 const (
@@ -44,6 +46,11 @@ type Client struct {
 	userAgent   string
 	httpClient  *http.Client
 	endPoint    *url.URL
+	readOnly    bool
+	auditLog    *AuditLog
+	tracer      trace.Tracer
+	statusLock  sync.Mutex
+	status      Status
 }
 
 // New creates a new API Client using a given api token.
@@ -70,10 +77,17 @@ func (c *Client) SetUserAgent(userAgent string) {
 	c.userAgent = userAgent
 }
 
+// SetReadOnly makes the client refuse all requests which could change anything, i.e. POST, PUT and DELETE requests.
+func (c *Client) SetReadOnly(readOnly bool) {
+	c.readOnly = readOnly
+}
+
 func (c *Client) request(ctx context.Context, method string, path string, bodyJSON any) (*http.Response, error) {
 	uri := c.endPoint.String() + path
 
-	tflog.Debug(ctx, fmt.Sprintf("HTTP request to API %s %s", method, uri))
+	if c.readOnly && method != http.MethodGet && method != http.MethodHead {
+		return nil, fmt.Errorf("refusing %s request to %s: %w", method, path, ErrReadOnly)
+	}
 
 	var (
 		err     error
@@ -87,8 +101,12 @@ func (c *Client) request(ctx context.Context, method string, path string, bodyJS
 		}
 	}
 
+	ctx, span := c.startSpan(ctx, method, path, reqBody)
+
 	req, err := http.NewRequestWithContext(ctx, method, uri, bytes.NewReader(reqBody))
 	if err != nil {
+		endSpan(span, nil, err)
+
 		return nil, fmt.Errorf("error building request: %w", err)
 	}
 
@@ -107,12 +125,22 @@ func (c *Client) request(ctx context.Context, method string, path string, bodyJS
 		req.Header.Set("User-Agent", c.userAgent)
 	}
 
+	c.countRequest()
+
 	resp, err := c.httpClient.Do(req)
+	endSpan(span, resp, err)
+
+	if c.auditLog != nil && method != http.MethodGet && method != http.MethodHead {
+		if auditErr := c.audit(ctx, method, path, reqBody, resp, err); auditErr != nil {
+			tflog.Error(ctx, fmt.Sprintf("failed to write audit log entry for %s %s: %s", method, path, auditErr))
+		}
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %w", err)
 	}
 
-	tflog.Debug(ctx, "Rate limit remaining: "+resp.Header.Get(RateLimitRemainingHeader))
+	c.updateRateLimit(resp.Header)
 
 	switch resp.StatusCode {
 	case http.StatusUnauthorized:
@@ -131,10 +159,12 @@ func (c *Client) request(ctx context.Context, method string, path string, bodyJS
 
 		return nil, fmt.Errorf("API returned HTTP 422 Unprocessable Entity error with message: '%s'", unprocessableEntityError.Error.Message)
 	case http.StatusTooManyRequests:
-		tflog.Debug(ctx, "Rate limit limit: "+resp.Header.Get(RateLimitLimitHeader))
-		tflog.Debug(ctx, "Rate limit reset: "+resp.Header.Get(RateLimitResetHeader))
+		resp.Body.Close()
 
-		return nil, fmt.Errorf("API returned HTTP 429 Too Many Requests error: %w", ErrRateLimited)
+		return nil, fmt.Errorf("API returned HTTP 429 Too Many Requests error: %w", &StatusError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRateLimitReset(resp.Header),
+		})
 	}
 
 	return resp, nil
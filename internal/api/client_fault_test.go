@@ -0,0 +1,237 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fakeToken = "fake-token"
+
+func createFakeClient(t *testing.T, faults ...fake.Fault) (*Client, *fake.Server, *Zone) {
+	t.Helper()
+
+	server := fake.NewServer(fakeToken)
+	t.Cleanup(server.Close)
+
+	client, err := New(server.URL, fakeToken, http.DefaultTransport)
+	require.NoError(t, err)
+
+	zone, err := client.CreateZone(context.Background(), CreateZoneOpts{Name: "example.com", TTL: 3600})
+	require.NoError(t, err)
+
+	server.InjectFaults(faults...)
+
+	return client, server, zone
+}
+
+func TestClientFaultsGetZone(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		fault       fake.Fault
+		timeout     time.Duration
+		errIs       error
+		errContains string
+	}{
+		{
+			name:  "rate limited",
+			fault: fake.Fault{Kind: fake.FaultRateLimit, Reset: 30 * time.Second},
+			errIs: ErrRateLimited,
+		},
+		{
+			name:        "internal server error",
+			fault:       fake.Fault{Kind: fake.FaultErrorResponse},
+			errContains: "http status 500 unhandled",
+		},
+		{
+			name:        "service unavailable",
+			fault:       fake.Fault{Kind: fake.FaultErrorResponse, Status: http.StatusServiceUnavailable},
+			errContains: "http status 503 unhandled",
+		},
+		{
+			name:        "unauthorized",
+			fault:       fake.Fault{Kind: fake.FaultErrorResponse, Status: http.StatusUnauthorized},
+			errContains: "API returned HTTP 401 Unauthorized error with message: 'injected fault'",
+		},
+		{
+			name:        "unauthorized with malformed body",
+			fault:       fake.Fault{Kind: fake.FaultErrorResponse, Status: http.StatusUnauthorized, Body: "<html>"},
+			errContains: "error parsing JSON response body",
+		},
+		{
+			name:        "unprocessable entity",
+			fault:       fake.Fault{Kind: fake.FaultErrorResponse, Status: http.StatusUnprocessableEntity},
+			errContains: "API returned HTTP 422 Unprocessable Entity error with message: 'injected fault'",
+		},
+		{
+			name:        "unprocessable entity with malformed body",
+			fault:       fake.Fault{Kind: fake.FaultErrorResponse, Status: http.StatusUnprocessableEntity, Body: "<html>"},
+			errContains: "error parsing JSON response body: invalid character '<'",
+		},
+		{
+			name:  "slow response",
+			fault: fake.Fault{Kind: fake.FaultSlowResponse, Delay: 10 * time.Millisecond},
+		},
+		{
+			name:    "slow response exceeding timeout",
+			fault:   fake.Fault{Kind: fake.FaultSlowResponse, Delay: time.Minute},
+			timeout: 50 * time.Millisecond,
+			errIs:   context.DeadlineExceeded,
+		},
+		{
+			name:        "malformed JSON",
+			fault:       fake.Fault{Kind: fake.FaultMalformedJSON},
+			errContains: "error parsing JSON response body",
+		},
+		{
+			name:        "truncated body",
+			fault:       fake.Fault{Kind: fake.FaultTruncatedBody},
+			errContains: "unexpected EOF",
+		},
+		// The HTTP transport retries idempotent requests once if a reused connection is dropped.
+		{
+			name:        "dropped connection",
+			fault:       fake.Fault{Kind: fake.FaultDropConnection, Times: 2},
+			errContains: "error sending request",
+		},
+		{
+			name:  "fault for other requests",
+			fault: fake.Fault{Kind: fake.FaultErrorResponse, Path: "/api/v1/records"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, _, zone := createFakeClient(t, tc.fault)
+
+			ctx := context.Background()
+
+			if tc.timeout > 0 {
+				var cancel context.CancelFunc
+
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+
+			got, err := client.GetZone(ctx, zone.ID)
+
+			switch {
+			case tc.errIs != nil:
+				require.ErrorIs(t, err, tc.errIs)
+			case tc.errContains != "":
+				require.ErrorContains(t, err, tc.errContains)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, zone, got)
+			}
+		})
+	}
+}
+
+func TestClientFaultsCreateRecord(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name          string
+		fault         fake.Fault
+		errContains   string
+		recordCreated bool
+	}{
+		{
+			name:        "rate limited",
+			fault:       fake.Fault{Kind: fake.FaultRateLimit, Method: http.MethodPost},
+			errContains: ErrRateLimited.Error(),
+		},
+		{
+			name:        "bad gateway",
+			fault:       fake.Fault{Kind: fake.FaultErrorResponse, Method: http.MethodPost, Status: http.StatusBadGateway},
+			errContains: "http status 502 unhandled",
+		},
+		{
+			name:        "dropped connection",
+			fault:       fake.Fault{Kind: fake.FaultDropConnection, Method: http.MethodPost},
+			errContains: "error sending request",
+		},
+		// The record is created even though the response can't be read.
+		{
+			name:          "malformed JSON",
+			fault:         fake.Fault{Kind: fake.FaultMalformedJSON, Method: http.MethodPost},
+			errContains:   "error parsing JSON response body",
+			recordCreated: true,
+		},
+		{
+			name:          "truncated body",
+			fault:         fake.Fault{Kind: fake.FaultTruncatedBody, Method: http.MethodPost},
+			errContains:   "unexpected EOF",
+			recordCreated: true,
+		},
+		{
+			name:          "slow response",
+			fault:         fake.Fault{Kind: fake.FaultSlowResponse, Method: http.MethodPost, Delay: 10 * time.Millisecond},
+			recordCreated: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, server, zone := createFakeClient(t, tc.fault)
+			defaultRecords := len(server.Records(zone.ID))
+
+			_, err := client.CreateRecord(context.Background(), CreateRecordOpts{ZoneID: zone.ID, Type: "A", Name: "www", Value: "192.0.2.1"})
+			if tc.errContains != "" {
+				require.ErrorContains(t, err, tc.errContains)
+			} else {
+				require.NoError(t, err)
+			}
+
+			created := len(server.Records(zone.ID)) - defaultRecords
+			assert.Equal(t, tc.recordCreated, created == 1)
+		})
+	}
+}
+
+func TestClientFaultsTimes(t *testing.T) {
+	t.Parallel()
+
+	client, server, zone := createFakeClient(t, fake.Fault{Kind: fake.FaultErrorResponse, Times: 2})
+
+	for range 2 {
+		_, err := client.GetZone(context.Background(), zone.ID)
+		require.ErrorContains(t, err, "http status 500 unhandled")
+	}
+
+	_, err := client.GetZone(context.Background(), zone.ID)
+	require.NoError(t, err)
+	assert.Zero(t, server.PendingFaults())
+}
+
+func TestClientReadOnly(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, server, zone := createFakeClient(t)
+	client.SetReadOnly(true)
+
+	requests := server.Requests()
+
+	_, err := client.CreateRecord(ctx, CreateRecordOpts{ZoneID: zone.ID, Type: "A", Name: "www", Value: "192.0.2.1"})
+	require.ErrorIs(t, err, ErrReadOnly)
+
+	_, err = client.UpdateZone(ctx, Zone{ID: zone.ID, Name: zone.Name, TTL: 60})
+	require.ErrorIs(t, err, ErrReadOnly)
+
+	err = client.DeleteZone(ctx, zone.ID)
+	require.ErrorIs(t, err, ErrReadOnly)
+	assert.Equal(t, requests, server.Requests())
+
+	got, err := client.GetZone(ctx, zone.ID)
+	require.NoError(t, err)
+	assert.Equal(t, zone, got)
+}
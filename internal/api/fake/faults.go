@@ -0,0 +1,170 @@
+package fake
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FaultKind is the kind of failure injected by a Fault.
+type FaultKind int
+
+const (
+	// FaultRateLimit answers the request with HTTP 429 Too Many Requests and rate limit headers.
+	FaultRateLimit FaultKind = iota + 1
+	// FaultErrorResponse answers the request with the status code of the fault, HTTP 500 by default.
+	FaultErrorResponse
+	// FaultSlowResponse delays the request by the delay of the fault before it is handled.
+	FaultSlowResponse
+	// FaultMalformedJSON handles the request but replaces the response body with invalid JSON.
+	FaultMalformedJSON
+	// FaultTruncatedBody handles the request but closes the connection after half of the response body.
+	FaultTruncatedBody
+	// FaultDropConnection closes the connection without handling the request.
+	FaultDropConnection
+)
+
+// defaultFaultReset is the rate limit reset of a FaultRateLimit without a reset.
+const defaultFaultReset = time.Second
+
+// Fault is a failure injected into the responses of matching requests.
+type Fault struct {
+	Kind FaultKind
+	// Method is the HTTP method of matching requests. All methods match if it is empty.
+	Method string
+	// Path is the prefix of the URL path of matching requests. All paths match if it is empty.
+	Path string
+	// Times is the number of matching requests the fault is injected into. It defaults to 1.
+	Times int
+	// Status is the status code of a FaultErrorResponse.
+	Status int
+	// Body replaces the response body of a FaultErrorResponse.
+	Body string
+	// Delay is the delay of a FaultSlowResponse.
+	Delay time.Duration
+	// Reset is the time until the rate limit of a FaultRateLimit resets. It defaults to 1 second.
+	Reset time.Duration
+}
+
+// WithFaults configures faults which are injected into the responses of matching requests.
+func WithFaults(faults ...Fault) Option {
+	return func(s *Server) {
+		s.addFaults(faults)
+	}
+}
+
+// InjectFaults adds faults which are injected into the responses of matching requests. Faults are
+// applied in the order in which they were added, every request is affected by one fault at most.
+func (s *Server) InjectFaults(faults ...Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.addFaults(faults)
+}
+
+// PendingFaults returns the number of times faults will still be injected.
+func (s *Server) PendingFaults() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var pending int
+	for _, f := range s.faults {
+		pending += f.Times
+	}
+
+	return pending
+}
+
+// addFaults adds faults to the script. The caller must hold s.mu unless the server is not started yet.
+func (s *Server) addFaults(faults []Fault) {
+	for _, f := range faults {
+		if f.Times == 0 {
+			f.Times = 1
+		}
+
+		s.faults = append(s.faults, f)
+	}
+}
+
+// nextFault returns the first pending fault matching the request and counts it as injected.
+// The caller must hold s.mu.
+func (s *Server) nextFault(r *http.Request) (Fault, bool) {
+	for i, f := range s.faults {
+		if f.Times <= 0 || (f.Method != "" && f.Method != r.Method) || !strings.HasPrefix(r.URL.Path, f.Path) {
+			continue
+		}
+
+		s.faults[i].Times--
+
+		return f, true
+	}
+
+	return Fault{}, false
+}
+
+// injectFault writes the response for a request affected by the given fault.
+func injectFault(w http.ResponseWriter, r *http.Request, next http.Handler, f Fault) {
+	switch f.Kind {
+	case FaultRateLimit:
+		reset := f.Reset
+		if reset == 0 {
+			reset = defaultFaultReset
+		}
+
+		w.Header().Set("ratelimit-remaining", "0")
+		w.Header().Set("ratelimit-reset", strconv.Itoa(int(reset.Seconds())))
+		writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+	case FaultErrorResponse:
+		status := f.Status
+		if status == 0 {
+			status = http.StatusInternalServerError
+		}
+
+		if f.Body != "" {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(f.Body))
+
+			return
+		}
+
+		if status == http.StatusUnauthorized {
+			writeJSON(w, status, map[string]string{"message": "injected fault"})
+
+			return
+		}
+
+		writeError(w, status, "injected fault")
+	case FaultSlowResponse:
+		select {
+		case <-time.After(f.Delay):
+			next.ServeHTTP(w, r)
+		case <-r.Context().Done():
+		}
+	case FaultMalformedJSON, FaultTruncatedBody:
+		rec := httptest.NewRecorder()
+		next.ServeHTTP(rec, r)
+
+		for key, values := range rec.Header() {
+			w.Header()[key] = values
+		}
+
+		body := rec.Body.Bytes()
+		if f.Kind == FaultMalformedJSON {
+			body = []byte(`{"malformed":`)
+		} else {
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			body = body[:len(body)/2]
+		}
+
+		w.WriteHeader(rec.Code)
+		_, _ = bytes.NewReader(body).WriteTo(w)
+	case FaultDropConnection:
+		// Aborting the handler makes the server close the connection without sending a response.
+		panic(http.ErrAbortHandler)
+	default:
+		next.ServeHTTP(w, r)
+	}
+}
@@ -0,0 +1,189 @@
+package fake
+
+import (
+	"net"
+	"net/http"
+	"slices"
+)
+
+// PrimaryServer is a primary server of a secondary zone as returned by the API.
+type PrimaryServer struct {
+	ID       string `json:"id"`
+	ZoneID   string `json:"zone_id"`
+	Address  string `json:"address"`
+	Port     int64  `json:"port"`
+	Created  string `json:"created"`
+	Modified string `json:"modified"`
+}
+
+type primaryServerRequest struct {
+	ZoneID  string `json:"zone_id"`
+	Address string `json:"address"`
+	Port    int64  `json:"port"`
+}
+
+type primaryServerResponse struct {
+	PrimaryServer PrimaryServer `json:"primary_server"`
+}
+
+type primaryServersResponse struct {
+	PrimaryServers []PrimaryServer `json:"primary_servers"`
+	Meta           meta            `json:"meta"`
+}
+
+func (s *Server) listPrimaryServers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zoneID := r.URL.Query().Get("zone_id")
+	if zoneID != "" && s.findZone(zoneID) == nil {
+		writeError(w, http.StatusNotFound, "zone not found")
+
+		return
+	}
+
+	servers := make([]PrimaryServer, 0, len(s.primaryServers))
+
+	for _, ps := range s.primaryServers {
+		if zoneID == "" || ps.ZoneID == zoneID {
+			servers = append(servers, *ps)
+		}
+	}
+
+	page, m, ok := paginate(r, servers)
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, "invalid pagination parameters")
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, primaryServersResponse{PrimaryServers: page, Meta: m})
+}
+
+func (s *Server) createPrimaryServer(w http.ResponseWriter, r *http.Request) {
+	var req primaryServerRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findZone(req.ZoneID) == nil {
+		writeError(w, http.StatusNotFound, "zone not found")
+
+		return
+	}
+
+	if msg := checkPrimaryServer(req); msg != "" {
+		writeError(w, http.StatusUnprocessableEntity, msg)
+
+		return
+	}
+
+	now := timestamp()
+	ps := &PrimaryServer{
+		ID:       newID(),
+		ZoneID:   req.ZoneID,
+		Address:  req.Address,
+		Port:     req.Port,
+		Created:  now,
+		Modified: now,
+	}
+	s.primaryServers = append(s.primaryServers, ps)
+
+	writeJSON(w, http.StatusOK, primaryServerResponse{PrimaryServer: *ps})
+}
+
+func (s *Server) getPrimaryServer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ps := s.findPrimaryServer(r.PathValue("id"))
+	if ps == nil {
+		writeError(w, http.StatusNotFound, "primary server not found")
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, primaryServerResponse{PrimaryServer: *ps})
+}
+
+func (s *Server) updatePrimaryServer(w http.ResponseWriter, r *http.Request) {
+	var req primaryServerRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ps := s.findPrimaryServer(r.PathValue("id"))
+	if ps == nil {
+		writeError(w, http.StatusNotFound, "primary server not found")
+
+		return
+	}
+
+	if req.ZoneID == "" {
+		req.ZoneID = ps.ZoneID
+	}
+
+	if s.findZone(req.ZoneID) == nil {
+		writeError(w, http.StatusNotFound, "zone not found")
+
+		return
+	}
+
+	if msg := checkPrimaryServer(req); msg != "" {
+		writeError(w, http.StatusUnprocessableEntity, msg)
+
+		return
+	}
+
+	ps.ZoneID = req.ZoneID
+	ps.Address = req.Address
+	ps.Port = req.Port
+	ps.Modified = timestamp()
+
+	writeJSON(w, http.StatusOK, primaryServerResponse{PrimaryServer: *ps})
+}
+
+func (s *Server) deletePrimaryServer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if s.findPrimaryServer(id) == nil {
+		writeError(w, http.StatusNotFound, "primary server not found")
+
+		return
+	}
+
+	s.primaryServers = slices.DeleteFunc(s.primaryServers, func(ps *PrimaryServer) bool { return ps.ID == id })
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// findPrimaryServer returns the primary server with the given ID. The caller must hold s.mu.
+func (s *Server) findPrimaryServer(id string) *PrimaryServer {
+	for _, ps := range s.primaryServers {
+		if ps.ID == id {
+			return ps
+		}
+	}
+
+	return nil
+}
+
+// checkPrimaryServer returns the message of the 422 response for an invalid primary server or an empty string.
+func checkPrimaryServer(req primaryServerRequest) string {
+	switch {
+	case net.ParseIP(req.Address) == nil:
+		return "invalid primary server address " + req.Address
+	case req.Port < 1 || req.Port > 65535:
+		return "invalid primary server port"
+	}
+
+	return ""
+}
@@ -0,0 +1,231 @@
+package fake
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// recordTypes are the record types supported by the API.
+//
+//nolint:gochecknoglobals
+var recordTypes = []string{"A", "AAAA", "CAA", "CNAME", "DANE", "DS", "HINFO", "MX", "NS", "PTR", "RP", "SOA", "SRV", "TLSA", "TXT"}
+
+// Record is a DNS record as returned by the API.
+type Record struct {
+	ID       string `json:"id"`
+	ZoneID   string `json:"zone_id"`
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	TTL      *int64 `json:"ttl,omitempty"`
+	Created  string `json:"created"`
+	Modified string `json:"modified"`
+}
+
+type recordRequest struct {
+	ZoneID string `json:"zone_id"`
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	TTL    *int64 `json:"ttl"`
+}
+
+type recordResponse struct {
+	Record Record `json:"record"`
+}
+
+type recordsResponse struct {
+	Records []Record `json:"records"`
+	Meta    meta     `json:"meta"`
+}
+
+// Records returns copies of all records of the zone with the given ID.
+func (s *Server) Records(zoneID string) []Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records := make([]Record, 0, len(s.records))
+
+	for _, rec := range s.records {
+		if rec.ZoneID == zoneID {
+			records = append(records, *rec)
+		}
+	}
+
+	return records
+}
+
+func (s *Server) listRecords(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zoneID := r.URL.Query().Get("zone_id")
+	if zoneID != "" && s.findZone(zoneID) == nil {
+		writeError(w, http.StatusNotFound, "zone not found")
+
+		return
+	}
+
+	records := make([]Record, 0, len(s.records))
+
+	for _, rec := range s.records {
+		if zoneID == "" || rec.ZoneID == zoneID {
+			records = append(records, *rec)
+		}
+	}
+
+	page, m, ok := paginate(r, records)
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, "invalid pagination parameters")
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, recordsResponse{Records: page, Meta: m})
+}
+
+func (s *Server) createRecord(w http.ResponseWriter, r *http.Request) {
+	var req recordRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findZone(req.ZoneID) == nil {
+		writeError(w, http.StatusNotFound, "zone not found")
+
+		return
+	}
+
+	if msg := checkRecord(req); msg != "" {
+		writeError(w, http.StatusUnprocessableEntity, msg)
+
+		return
+	}
+
+	now := timestamp()
+	rec := &Record{
+		ID:       newID(),
+		ZoneID:   req.ZoneID,
+		Type:     req.Type,
+		Name:     req.Name,
+		Value:    req.Value,
+		TTL:      req.TTL,
+		Created:  now,
+		Modified: now,
+	}
+	s.normalizeRecord(rec)
+	s.records = append(s.records, rec)
+
+	writeJSON(w, http.StatusOK, recordResponse{Record: *rec})
+}
+
+func (s *Server) getRecord(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec := s.findRecord(r.PathValue("id"))
+	if rec == nil {
+		writeError(w, http.StatusNotFound, "record not found")
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, recordResponse{Record: *rec})
+}
+
+func (s *Server) updateRecord(w http.ResponseWriter, r *http.Request) {
+	var req recordRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec := s.findRecord(r.PathValue("id"))
+	if rec == nil {
+		writeError(w, http.StatusNotFound, "record not found")
+
+		return
+	}
+
+	if req.ZoneID == "" {
+		req.ZoneID = rec.ZoneID
+	}
+
+	if s.findZone(req.ZoneID) == nil {
+		writeError(w, http.StatusNotFound, "zone not found")
+
+		return
+	}
+
+	if msg := checkRecord(req); msg != "" {
+		writeError(w, http.StatusUnprocessableEntity, msg)
+
+		return
+	}
+
+	rec.ZoneID = req.ZoneID
+	rec.Type = req.Type
+	rec.Name = req.Name
+	rec.Value = req.Value
+	rec.TTL = req.TTL
+	rec.Modified = timestamp()
+	s.normalizeRecord(rec)
+
+	writeJSON(w, http.StatusOK, recordResponse{Record: *rec})
+}
+
+func (s *Server) deleteRecord(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if s.findRecord(id) == nil {
+		writeError(w, http.StatusNotFound, "record not found")
+
+		return
+	}
+
+	s.records = slices.DeleteFunc(s.records, func(rec *Record) bool { return rec.ID == id })
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// findRecord returns the record with the given ID. The caller must hold s.mu.
+func (s *Server) findRecord(id string) *Record {
+	for _, rec := range s.records {
+		if rec.ID == id {
+			return rec
+		}
+	}
+
+	return nil
+}
+
+// normalizeRecord applies the record normalization of the server, see WithRecordNormalization.
+func (s *Server) normalizeRecord(rec *Record) {
+	if s.normalize != nil {
+		s.normalize(rec)
+	}
+}
+
+// checkRecord returns the message of the 422 response for an invalid record or an empty string.
+func checkRecord(req recordRequest) string {
+	switch {
+	case !slices.Contains(recordTypes, req.Type):
+		return "invalid record type " + req.Type
+	case strings.TrimSpace(req.Name) == "":
+		return "record name must not be empty"
+	case strings.TrimSpace(req.Value) == "":
+		return "record value must not be empty"
+	case req.TTL != nil && *req.TTL < 0:
+		return "record ttl must not be negative"
+	}
+
+	return ""
+}
@@ -0,0 +1,289 @@
+// Package fake provides an in-memory implementation of the Hetzner DNS API for tests.
+//
+// The server implements zones, records and primary servers including pagination, the 401, 404 and 422
+// error responses, the default SOA and NS records of new zones and the rate limit headers of the real API.
+// Faults like rate limiting, server errors, slow responses, malformed or truncated bodies and dropped
+// connections can be injected into chosen requests to test the error handling of the client.
+// It deliberately does not import the api package, so it can be used by the tests of that package.
+package fake
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRateLimit is the number of requests allowed per rate limit window if no other limit is configured.
+	DefaultRateLimit = 100
+	// DefaultRateLimitWindow is the duration of a rate limit window if no other window is configured.
+	DefaultRateLimitWindow = time.Second
+
+	defaultZoneTTL = 86400
+	defaultPerPage = 100
+	maxPerPage     = 100
+)
+
+// Nameservers are the authoritative nameservers assigned to every zone.
+//
+//nolint:gochecknoglobals
+var Nameservers = []string{"hydrogen.ns.hetzner.com", "oxygen.ns.hetzner.com", "helium.ns.hetzner.de"}
+
+// Server is an in-memory Hetzner DNS API served by an httptest.Server.
+type Server struct {
+	// URL is the base URL of the server, which can be passed as API endpoint to the API client.
+	URL string
+
+	token      string
+	tls        bool
+	httpServer *httptest.Server
+
+	mu             sync.Mutex
+	zones          []*Zone
+	records        []*Record
+	primaryServers []*PrimaryServer
+	requests       int
+	faults         []Fault
+	normalize      func(*Record)
+
+	rateLimit       int
+	rateLimitWindow time.Duration
+	windowStart     time.Time
+	windowRequests  int
+	now             func() time.Time
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithRateLimit configures the number of requests allowed per window. Further requests are answered with
+// HTTP 429 Too Many Requests until the window ends.
+func WithRateLimit(limit int, window time.Duration) Option {
+	return func(s *Server) {
+		s.rateLimit = limit
+		s.rateLimitWindow = window
+	}
+}
+
+// WithTLS makes the server use HTTPS with a self-signed certificate, see CertificatePEM.
+func WithTLS() Option {
+	return func(s *Server) {
+		s.tls = true
+	}
+}
+
+// WithRecordNormalization configures a function which changes the name or value of created and updated records
+// before they are stored, e.g. to mimic the real API storing an equivalent spelling of a value.
+func WithRecordNormalization(normalize func(*Record)) Option {
+	return func(s *Server) {
+		s.normalize = normalize
+	}
+}
+
+// NewServer starts a new fake API server which accepts the given API token. It must be closed with Close.
+func NewServer(token string, opts ...Option) *Server {
+	s := &Server{
+		token:           token,
+		rateLimit:       DefaultRateLimit,
+		rateLimitWindow: DefaultRateLimitWindow,
+		now:             time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/zones", s.listZones)
+	mux.HandleFunc("POST /api/v1/zones", s.createZone)
+	mux.HandleFunc("GET /api/v1/zones/{id}", s.getZone)
+	mux.HandleFunc("PUT /api/v1/zones/{id}", s.updateZone)
+	mux.HandleFunc("DELETE /api/v1/zones/{id}", s.deleteZone)
+	mux.HandleFunc("GET /api/v1/records", s.listRecords)
+	mux.HandleFunc("POST /api/v1/records", s.createRecord)
+	mux.HandleFunc("GET /api/v1/records/{id}", s.getRecord)
+	mux.HandleFunc("PUT /api/v1/records/{id}", s.updateRecord)
+	mux.HandleFunc("DELETE /api/v1/records/{id}", s.deleteRecord)
+	mux.HandleFunc("GET /api/v1/primary_servers", s.listPrimaryServers)
+	mux.HandleFunc("POST /api/v1/primary_servers", s.createPrimaryServer)
+	mux.HandleFunc("GET /api/v1/primary_servers/{id}", s.getPrimaryServer)
+	mux.HandleFunc("PUT /api/v1/primary_servers/{id}", s.updatePrimaryServer)
+	mux.HandleFunc("DELETE /api/v1/primary_servers/{id}", s.deletePrimaryServer)
+
+	s.httpServer = httptest.NewUnstartedServer(s.middleware(mux))
+	if s.tls {
+		s.httpServer.StartTLS()
+	} else {
+		s.httpServer.Start()
+	}
+
+	s.URL = s.httpServer.URL
+
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.httpServer.Close()
+}
+
+// CertificatePEM returns the PEM encoded certificate of a server started with WithTLS.
+func (s *Server) CertificatePEM() string {
+	if s.httpServer.Certificate() == nil {
+		return ""
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.httpServer.Certificate().Raw}))
+}
+
+// Requests returns the number of requests the server has received.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+// middleware counts requests, checks the API token, injects faults and applies the rate limit.
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests++
+		allowed := s.applyRateLimit(w.Header())
+		fault, faulty := s.nextFault(r)
+		s.mu.Unlock()
+
+		if r.Header.Get("Auth-API-Token") != s.token {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"message": "Invalid authentication credentials"})
+
+			return
+		}
+
+		if faulty {
+			injectFault(w, r, next, fault)
+
+			return
+		}
+
+		if !allowed {
+			writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// applyRateLimit counts the request against the current rate limit window and sets the rate limit headers.
+// It reports whether the request is allowed. The caller must hold s.mu.
+func (s *Server) applyRateLimit(header http.Header) bool {
+	now := s.now()
+	if now.Sub(s.windowStart) >= s.rateLimitWindow {
+		s.windowStart = now
+		s.windowRequests = 0
+	}
+
+	s.windowRequests++
+
+	remaining := max(s.rateLimit-s.windowRequests, 0)
+	reset := s.windowStart.Add(s.rateLimitWindow).Sub(now)
+
+	header.Set("ratelimit-limit", strconv.Itoa(s.rateLimit))
+	header.Set("ratelimit-remaining", strconv.Itoa(remaining))
+	header.Set("ratelimit-reset", strconv.Itoa(int(reset.Round(time.Second).Seconds())))
+
+	return s.windowRequests <= s.rateLimit
+}
+
+type errorResponse struct {
+	Error errorMessage `json:"error"`
+}
+
+type errorMessage struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type meta struct {
+	Pagination pagination `json:"pagination"`
+}
+
+type pagination struct {
+	Page         int `json:"page"`
+	PerPage      int `json:"per_page"`
+	PreviousPage int `json:"previous_page"`
+	NextPage     int `json:"next_page"`
+	LastPage     int `json:"last_page"`
+	TotalEntries int `json:"total_entries"`
+}
+
+// paginate returns the requested page of items along with the pagination metadata.
+func paginate[T any](r *http.Request, items []T) ([]T, meta, bool) {
+	page, perPage := 1, defaultPerPage
+
+	if v := r.URL.Query().Get("page"); v != "" {
+		p, err := strconv.Atoi(v)
+		if err != nil || p < 1 {
+			return nil, meta{}, false
+		}
+
+		page = p
+	}
+
+	if v := r.URL.Query().Get("per_page"); v != "" {
+		p, err := strconv.Atoi(v)
+		if err != nil || p < 1 {
+			return nil, meta{}, false
+		}
+
+		perPage = min(p, maxPerPage)
+	}
+
+	lastPage := max((len(items)+perPage-1)/perPage, 1)
+	start := min((page-1)*perPage, len(items))
+	end := min(start+perPage, len(items))
+
+	return items[start:end], meta{Pagination: pagination{
+		Page:         page,
+		PerPage:      perPage,
+		PreviousPage: max(page-1, 1),
+		NextPage:     min(page+1, lastPage),
+		LastPage:     lastPage,
+		TotalEntries: len(items),
+	}}, true
+}
+
+func newID() string {
+	return rand.Text()[:22]
+}
+
+func timestamp() string {
+	return time.Now().UTC().Format("2006-01-02 15:04:05.000 +0000 UTC")
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "invalid request body: "+err.Error())
+
+		return false
+	}
+
+	return true
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: errorMessage{Message: message, Code: status}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,259 @@
+package fake_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api"
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "test-token"
+
+func newTestClient(t *testing.T, server *fake.Server, token string) *api.Client {
+	t.Helper()
+
+	client, err := api.New(server.URL, token, http.DefaultTransport)
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestServerZones(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer(testToken)
+	defer server.Close()
+
+	client := newTestClient(t, server, testToken)
+	ctx := context.Background()
+
+	_, err := client.GetZones(ctx)
+	require.ErrorIs(t, err, api.ErrNotFound)
+
+	zone, err := client.CreateZone(ctx, api.CreateZoneOpts{Name: "example.com", TTL: 3600})
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", zone.Name)
+	assert.Equal(t, int64(3600), zone.TTL)
+	assert.Equal(t, fake.Nameservers, zone.NS)
+
+	_, err = client.CreateZone(ctx, api.CreateZoneOpts{Name: "example.com", TTL: 3600})
+	require.ErrorContains(t, err, "zone already exists")
+
+	_, err = client.CreateZone(ctx, api.CreateZoneOpts{Name: "exa_mple.com", TTL: 3600})
+	require.ErrorContains(t, err, "422")
+
+	byName, err := client.GetZoneByName(ctx, "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, zone.ID, byName.ID)
+
+	_, err = client.GetZoneByName(ctx, "example.org")
+	require.ErrorIs(t, err, api.ErrNotFound)
+
+	zone.TTL = 7200
+	updated, err := client.UpdateZone(ctx, *zone)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7200), updated.TTL)
+
+	require.NoError(t, client.DeleteZone(ctx, zone.ID))
+
+	_, err = client.GetZone(ctx, zone.ID)
+	require.ErrorIs(t, err, api.ErrNotFound)
+
+	_, ok := server.ZoneByName("example.com")
+	assert.False(t, ok)
+}
+
+func TestServerDefaultRecords(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer(testToken)
+	defer server.Close()
+
+	client := newTestClient(t, server, testToken)
+
+	zone, err := client.CreateZone(context.Background(), api.CreateZoneOpts{Name: "example.com", TTL: 3600})
+	require.NoError(t, err)
+
+	records, err := client.GetRecordsByZoneID(context.Background(), zone.ID)
+	require.NoError(t, err)
+
+	types := make(map[string]int)
+
+	for _, record := range *records {
+		assert.Equal(t, "@", record.Name)
+
+		types[record.Type]++
+	}
+
+	assert.Equal(t, map[string]int{"SOA": 1, "NS": len(fake.Nameservers)}, types)
+}
+
+func TestServerRecords(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer(testToken)
+	defer server.Close()
+
+	client := newTestClient(t, server, testToken)
+	ctx := context.Background()
+
+	zone, err := client.CreateZone(ctx, api.CreateZoneOpts{Name: "example.com", TTL: 3600})
+	require.NoError(t, err)
+
+	ttl := int64(60)
+	record, err := client.CreateRecord(ctx, api.CreateRecordOpts{ZoneID: zone.ID, Type: "A", Name: "www", Value: "192.0.2.1", TTL: &ttl})
+	require.NoError(t, err)
+	assert.Equal(t, api.Record{ZoneID: zone.ID, ID: record.ID, Type: "A", Name: "www", Value: "192.0.2.1", TTL: &ttl}, *record)
+
+	_, err = client.CreateRecord(ctx, api.CreateRecordOpts{ZoneID: "unknown", Type: "A", Name: "www", Value: "192.0.2.1"})
+	require.ErrorIs(t, err, api.ErrNotFound)
+
+	_, err = client.CreateRecord(ctx, api.CreateRecordOpts{ZoneID: zone.ID, Type: "UNKNOWN", Name: "www", Value: "192.0.2.1"})
+	require.ErrorContains(t, err, "invalid record type")
+
+	record.Value = "192.0.2.2"
+	updated, err := client.UpdateRecord(ctx, *record)
+	require.NoError(t, err)
+	assert.Equal(t, "192.0.2.2", updated.Value)
+
+	read, err := client.GetRecord(ctx, record.ID)
+	require.NoError(t, err)
+	assert.Equal(t, *updated, *read)
+
+	require.NoError(t, client.DeleteRecord(ctx, record.ID))
+
+	_, err = client.GetRecord(ctx, record.ID)
+	require.ErrorIs(t, err, api.ErrNotFound)
+
+	assert.Len(t, server.Records(zone.ID), 1+len(fake.Nameservers))
+}
+
+func TestServerPrimaryServers(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer(testToken)
+	defer server.Close()
+
+	client := newTestClient(t, server, testToken)
+	ctx := context.Background()
+
+	zone, err := client.CreateZone(ctx, api.CreateZoneOpts{Name: "example.com", TTL: 3600})
+	require.NoError(t, err)
+
+	primaryServer, err := client.CreatePrimaryServer(ctx, api.CreatePrimaryServerRequest{ZoneID: zone.ID, Address: "192.0.2.1", Port: 53})
+	require.NoError(t, err)
+
+	_, err = client.CreatePrimaryServer(ctx, api.CreatePrimaryServerRequest{ZoneID: zone.ID, Address: "ns1", Port: 53})
+	require.ErrorContains(t, err, "invalid primary server address")
+
+	primaryServer.Port = 5353
+	updated, err := client.UpdatePrimaryServer(ctx, *primaryServer)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5353), updated.Port)
+
+	primaryServers, err := client.GetPrimaryServers(ctx, zone.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []api.PrimaryServer{*updated}, primaryServers)
+
+	// Deleting a zone deletes its primary servers as well.
+	require.NoError(t, client.DeleteZone(ctx, zone.ID))
+
+	_, err = client.GetPrimaryServer(ctx, primaryServer.ID)
+	require.ErrorIs(t, err, api.ErrNotFound)
+}
+
+func TestServerUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer(testToken)
+	defer server.Close()
+
+	client := newTestClient(t, server, "invalid")
+
+	_, err := client.GetZones(context.Background())
+	require.ErrorContains(t, err, "401 Unauthorized")
+}
+
+func TestServerRateLimit(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer(testToken, fake.WithRateLimit(2, time.Minute))
+	defer server.Close()
+
+	client := newTestClient(t, server, testToken)
+
+	for range 2 {
+		_, err := client.GetZones(context.Background())
+		require.ErrorIs(t, err, api.ErrNotFound)
+	}
+
+	_, err := client.GetZones(context.Background())
+	require.ErrorIs(t, err, api.ErrRateLimited)
+	assert.Equal(t, 3, server.Requests())
+
+	resp := get(t, server, "/api/v1/zones")
+	defer resp.Body.Close()
+
+	assert.Equal(t, "2", resp.Header.Get(api.RateLimitLimitHeader))
+	assert.Equal(t, "0", resp.Header.Get(api.RateLimitRemainingHeader))
+	assert.Equal(t, "60", resp.Header.Get(api.RateLimitResetHeader))
+}
+
+func TestServerPagination(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer(testToken)
+	defer server.Close()
+
+	client := newTestClient(t, server, testToken)
+
+	for _, name := range []string{"a.com", "b.com", "c.com"} {
+		_, err := client.CreateZone(context.Background(), api.CreateZoneOpts{Name: name, TTL: 3600})
+		require.NoError(t, err)
+	}
+
+	resp := get(t, server, "/api/v1/zones?page=2&per_page=2")
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Zones []api.Zone `json:"zones"`
+		Meta  struct {
+			Pagination struct {
+				Page         int `json:"page"`
+				PerPage      int `json:"per_page"`
+				LastPage     int `json:"last_page"`
+				TotalEntries int `json:"total_entries"`
+			} `json:"pagination"`
+		} `json:"meta"`
+	}
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Len(t, body.Zones, 1)
+	assert.Equal(t, "c.com", body.Zones[0].Name)
+	assert.Equal(t, 2, body.Meta.Pagination.Page)
+	assert.Equal(t, 2, body.Meta.Pagination.PerPage)
+	assert.Equal(t, 2, body.Meta.Pagination.LastPage)
+	assert.Equal(t, 3, body.Meta.Pagination.TotalEntries)
+}
+
+func get(t *testing.T, server *fake.Server, path string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+path, nil)
+	require.NoError(t, err)
+
+	req.Header.Set("Auth-API-Token", testToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	return resp
+}
@@ -0,0 +1,262 @@
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Zone is a DNS zone as returned by the API.
+type Zone struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	TTL            int64    `json:"ttl"`
+	NS             []string `json:"ns"`
+	Created        string   `json:"created"`
+	Modified       string   `json:"modified"`
+	Status         string   `json:"status"`
+	IsSecondaryDNS bool     `json:"is_secondary_dns"`
+	RecordsCount   int      `json:"records_count"`
+}
+
+type zoneRequest struct {
+	Name string `json:"name"`
+	TTL  *int64 `json:"ttl"`
+}
+
+type zoneResponse struct {
+	Zone Zone `json:"zone"`
+}
+
+type zonesResponse struct {
+	Zones []Zone `json:"zones"`
+	Meta  meta   `json:"meta"`
+}
+
+// ZoneByName returns a copy of the zone with the given name, if it exists.
+func (s *Server) ZoneByName(name string) (Zone, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if z := s.findZoneByName(name); z != nil {
+		return s.zoneWithCount(z), true
+	}
+
+	return Zone{}, false
+}
+
+func (s *Server) listZones(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := r.URL.Query().Get("name")
+	zones := make([]Zone, 0, len(s.zones))
+
+	for _, z := range s.zones {
+		if name == "" || z.Name == name {
+			zones = append(zones, s.zoneWithCount(z))
+		}
+	}
+
+	// Like the real API, an empty result is answered with 404 Not Found.
+	if len(zones) == 0 {
+		writeError(w, http.StatusNotFound, "zone not found")
+
+		return
+	}
+
+	page, m, ok := paginate(r, zones)
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, "invalid pagination parameters")
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, zonesResponse{Zones: page, Meta: m})
+}
+
+func (s *Server) createZone(w http.ResponseWriter, r *http.Request) {
+	var req zoneRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := strings.ToLower(req.Name)
+	if err := checkZoneName(name); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+
+		return
+	}
+
+	if s.findZoneByName(name) != nil {
+		writeError(w, http.StatusUnprocessableEntity, "zone already exists")
+
+		return
+	}
+
+	ttl := int64(defaultZoneTTL)
+	if req.TTL != nil && *req.TTL > 0 {
+		ttl = *req.TTL
+	}
+
+	now := timestamp()
+	z := &Zone{
+		ID:       newID(),
+		Name:     name,
+		TTL:      ttl,
+		NS:       slices.Clone(Nameservers),
+		Created:  now,
+		Modified: now,
+		Status:   "verified",
+	}
+	s.zones = append(s.zones, z)
+
+	// New zones come with a SOA record and a NS record for every authoritative nameserver.
+	s.records = append(s.records, &Record{
+		ID:       newID(),
+		ZoneID:   z.ID,
+		Type:     "SOA",
+		Name:     "@",
+		Value:    Nameservers[0] + ". dns.hetzner.com. 2024010100 86400 10800 3600000 3600",
+		Created:  now,
+		Modified: now,
+	})
+
+	for _, ns := range Nameservers {
+		s.records = append(s.records, &Record{
+			ID:       newID(),
+			ZoneID:   z.ID,
+			Type:     "NS",
+			Name:     "@",
+			Value:    ns + ".",
+			Created:  now,
+			Modified: now,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, zoneResponse{Zone: s.zoneWithCount(z)})
+}
+
+func (s *Server) getZone(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	z := s.findZone(r.PathValue("id"))
+	if z == nil {
+		writeError(w, http.StatusNotFound, "zone not found")
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, zoneResponse{Zone: s.zoneWithCount(z)})
+}
+
+func (s *Server) updateZone(w http.ResponseWriter, r *http.Request) {
+	var req zoneRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	z := s.findZone(r.PathValue("id"))
+	if z == nil {
+		writeError(w, http.StatusNotFound, "zone not found")
+
+		return
+	}
+
+	if req.Name != "" && !strings.EqualFold(req.Name, z.Name) {
+		writeError(w, http.StatusUnprocessableEntity, "zone name can not be changed")
+
+		return
+	}
+
+	if req.TTL != nil {
+		z.TTL = *req.TTL
+	}
+
+	z.Modified = timestamp()
+
+	writeJSON(w, http.StatusOK, zoneResponse{Zone: s.zoneWithCount(z)})
+}
+
+func (s *Server) deleteZone(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if s.findZone(id) == nil {
+		writeError(w, http.StatusNotFound, "zone not found")
+
+		return
+	}
+
+	s.zones = slices.DeleteFunc(s.zones, func(z *Zone) bool { return z.ID == id })
+	s.records = slices.DeleteFunc(s.records, func(rec *Record) bool { return rec.ZoneID == id })
+	s.primaryServers = slices.DeleteFunc(s.primaryServers, func(ps *PrimaryServer) bool { return ps.ZoneID == id })
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// findZone returns the zone with the given ID. The caller must hold s.mu.
+func (s *Server) findZone(id string) *Zone {
+	for _, z := range s.zones {
+		if z.ID == id {
+			return z
+		}
+	}
+
+	return nil
+}
+
+// findZoneByName returns the zone with the given name. The caller must hold s.mu.
+func (s *Server) findZoneByName(name string) *Zone {
+	for _, z := range s.zones {
+		if strings.EqualFold(z.Name, name) {
+			return z
+		}
+	}
+
+	return nil
+}
+
+// zoneWithCount returns a copy of the zone with the current number of records. The caller must hold s.mu.
+func (s *Server) zoneWithCount(z *Zone) Zone {
+	c := *z
+	c.NS = slices.Clone(z.NS)
+
+	for _, rec := range s.records {
+		if rec.ZoneID == z.ID {
+			c.RecordsCount++
+		}
+	}
+
+	return c
+}
+
+func checkZoneName(name string) error {
+	labels := strings.Split(name, ".")
+	if len(labels) < 2 {
+		return fmt.Errorf("invalid zone name %q", name)
+	}
+
+	for _, label := range labels {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return fmt.Errorf("invalid zone name %q", name)
+		}
+
+		for _, c := range label {
+			if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+				return fmt.Errorf("invalid zone name %q", name)
+			}
+		}
+	}
+
+	return nil
+}
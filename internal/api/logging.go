@@ -0,0 +1,169 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LogSubsystem is the tflog subsystem of the API requests. Its level can be set separately from the
+// provider with the env variable TF_LOG_PROVIDER_HETZNERDNS_API.
+const LogSubsystem = "api"
+
+// sensitiveHeaders are the headers whose values are never logged.
+//
+//nolint:gochecknoglobals
+var sensitiveHeaders = map[string]bool{
+	"Auth-Api-Token":      true,
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// logSubsystemKey marks contexts to which the API subsystem of tflog has been added.
+type logSubsystemKey struct{}
+
+// WithLogSubsystem adds the API subsystem of tflog to the context, unless it has been added already.
+// Contexts without the subsystem get it added by the LoggingTransport on every request.
+func WithLogSubsystem(ctx context.Context) context.Context {
+	if ctx.Value(logSubsystemKey{}) != nil {
+		return ctx
+	}
+
+	ctx = tflog.NewSubsystem(ctx, LogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_HETZNERDNS", LogSubsystem))
+
+	return context.WithValue(ctx, logSubsystemKey{}, true)
+}
+
+// LoggingTransport logs the requests and responses of the wrapped transport to the API subsystem of tflog.
+// Values of authentication headers are replaced by a placeholder and are masked in all other fields too.
+type LoggingTransport struct {
+	transport http.RoundTripper
+	logBodies bool
+}
+
+// NewLoggingTransport returns a LoggingTransport wrapping the given transport. Request and response bodies
+// are only logged if logBodies is set.
+func NewLoggingTransport(transport http.RoundTripper, logBodies bool) *LoggingTransport {
+	return &LoggingTransport{transport: transport, logBodies: logBodies}
+}
+
+// RoundTrip logs the request, sends it with the wrapped transport and logs the response.
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := WithLogSubsystem(req.Context())
+
+	for name, values := range req.Header {
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, LogSubsystem, values...)
+			ctx = tflog.SubsystemMaskMessageStrings(ctx, LogSubsystem, values...)
+		}
+	}
+
+	ctx = tflog.SubsystemSetField(ctx, LogSubsystem, "http_method", req.Method)
+	ctx = tflog.SubsystemSetField(ctx, LogSubsystem, "http_url", req.URL.Redacted())
+
+	fields := map[string]any{"http_request_headers": logHeaders(req.Header)}
+
+	if t.logBodies && req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+
+		if err != nil {
+			return nil, fmt.Errorf("reading request body: %w", err)
+		}
+
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		fields["http_request_body"] = string(body)
+	}
+
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Sending API request", fields)
+
+	start := time.Now()
+
+	resp, err := t.transport.RoundTrip(req)
+
+	duration := time.Since(start)
+
+	if err != nil {
+		tflog.SubsystemDebug(ctx, LogSubsystem, "API request failed", map[string]any{
+			"http_duration_ms": duration.Milliseconds(),
+			"error":            err.Error(),
+		})
+
+		return nil, err //nolint:wrapcheck // The error of the wrapped transport is returned unchanged.
+	}
+
+	fields = map[string]any{
+		"http_status_code":      resp.StatusCode,
+		"http_duration_ms":      duration.Milliseconds(),
+		"http_response_headers": logHeaders(resp.Header),
+	}
+
+	for _, header := range []string{RateLimitLimitHeader, RateLimitRemainingHeader, RateLimitResetHeader} {
+		if value := resp.Header.Get(header); value != "" {
+			fields[strings.ReplaceAll(header, "-", "_")] = value
+		}
+	}
+
+	if t.logBodies && resp.Body != nil {
+		body, err := bufferBody(resp)
+		if err != nil {
+			fields["http_response_body_error"] = err.Error()
+		}
+
+		fields["http_response_body"] = string(body)
+	}
+
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Received API response", fields)
+
+	return resp, nil
+}
+
+// logHeaders returns the headers as a map for logging, with the values of sensitive headers redacted.
+func logHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+
+	for name, values := range header {
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			headers[name] = redacted
+
+			continue
+		}
+
+		headers[name] = strings.Join(values, ", ")
+	}
+
+	return headers
+}
+
+// bufferBody reads the body of the response and replaces it with the data which was read, so it can be read again.
+// The client gets the error of a failed read after the part of the body which was read.
+func bufferBody(resp *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	var reader io.Reader = bytes.NewReader(body)
+	if err != nil {
+		reader = io.MultiReader(reader, errorReader{err: err})
+	}
+
+	resp.Body = io.NopCloser(reader)
+
+	return body, err //nolint:wrapcheck // The error is returned again to the reader of the body.
+}
+
+// errorReader returns its error on every read.
+type errorReader struct {
+	err error
+}
+
+func (r errorReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api/fake"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggingTransport(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name      string
+		logBodies bool
+	}{
+		{
+			name: "without bodies",
+		},
+		{
+			name:      "with bodies",
+			logBodies: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := fake.NewServer(fakeToken)
+			t.Cleanup(server.Close)
+
+			client, err := New(server.URL, fakeToken, NewLoggingTransport(http.DefaultTransport, tc.logBodies))
+			require.NoError(t, err)
+
+			var output bytes.Buffer
+
+			ctx := tflogtest.RootLogger(context.Background(), &output)
+
+			zone, err := client.CreateZone(ctx, CreateZoneOpts{Name: "example.com", TTL: 3600})
+			require.NoError(t, err)
+
+			// The token is also sent in the body to check that it is masked everywhere.
+			_, err = client.CreateRecord(ctx, CreateRecordOpts{ZoneID: zone.ID, Type: "TXT", Name: "token", Value: fakeToken})
+			require.NoError(t, err)
+
+			assert.NotContains(t, output.String(), fakeToken)
+
+			entries, err := tflogtest.MultilineJSONDecode(&output)
+			require.NoError(t, err)
+
+			var apiEntries []map[string]any
+
+			for _, entry := range entries {
+				if entry["@module"] == "provider."+LogSubsystem {
+					apiEntries = append(apiEntries, entry)
+				}
+			}
+
+			require.Len(t, apiEntries, 4)
+
+			request, response := apiEntries[2], apiEntries[3]
+
+			assert.Equal(t, "Sending API request", request["@message"])
+			assert.Equal(t, http.MethodPost, request["http_method"])
+			assert.Equal(t, server.URL+"/api/v1/records", request["http_url"])
+			assert.Equal(t, redacted, request["http_request_headers"].(map[string]any)["Auth-Api-Token"])
+
+			assert.Equal(t, "Received API response", response["@message"])
+			assert.InDelta(t, http.StatusOK, response["http_status_code"], 0)
+			assert.Contains(t, response, "http_duration_ms")
+			assert.Contains(t, response, "ratelimit_remaining")
+
+			if !tc.logBodies {
+				assert.NotContains(t, request, "http_request_body")
+				assert.NotContains(t, response, "http_response_body")
+
+				return
+			}
+
+			assert.Contains(t, request["http_request_body"], `"name":"token"`)
+			assert.Contains(t, request["http_request_body"], "***")
+			assert.Contains(t, response["http_response_body"], `"record"`)
+		})
+	}
+}
+
+func TestLoggingTransportTruncatedBody(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer(fakeToken)
+	t.Cleanup(server.Close)
+
+	client, err := New(server.URL, fakeToken, NewLoggingTransport(http.DefaultTransport, true))
+	require.NoError(t, err)
+
+	zone, err := client.CreateZone(context.Background(), CreateZoneOpts{Name: "example.com", TTL: 3600})
+	require.NoError(t, err)
+
+	server.InjectFaults(fake.Fault{Kind: fake.FaultTruncatedBody})
+
+	var output bytes.Buffer
+
+	_, err = client.GetZone(tflogtest.RootLogger(context.Background(), &output), zone.ID)
+	require.Error(t, err)
+	assert.True(t, IsRetryable(err), err)
+	assert.Contains(t, output.String(), "http_response_body_error")
+}
+
+func TestLoggingTransportLogSubsystem(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer(fakeToken)
+	t.Cleanup(server.Close)
+
+	client, err := New(server.URL, fakeToken, NewLoggingTransport(http.DefaultTransport, false))
+	require.NoError(t, err)
+
+	var output bytes.Buffer
+
+	// Fields of the subsystem of the context are kept, because the transport doesn't create it again.
+	ctx := WithLogSubsystem(tflogtest.RootLogger(context.Background(), &output))
+	ctx = tflog.SubsystemSetField(ctx, LogSubsystem, "operation", "test")
+	ctx = WithLogSubsystem(ctx)
+
+	_, err = client.CreateZone(ctx, CreateZoneOpts{Name: "example.com", TTL: 3600})
+	require.NoError(t, err)
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	for _, entry := range entries {
+		assert.Equal(t, "provider."+LogSubsystem, entry["@module"])
+		assert.Equal(t, "test", entry["operation"])
+	}
+}
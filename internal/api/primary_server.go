@@ -41,12 +41,12 @@ func (c *Client) GetPrimaryServer(ctx context.Context, id string) (*PrimaryServe
 
 		err = readAndParseJSONBody(resp, &response)
 		if err != nil {
-			return nil, fmt.Errorf("error Reading json response of get primary server %s request: %s", id, err)
+			return nil, fmt.Errorf("error Reading json response of get primary server %s request: %w", id, err)
 		}
 
 		return &response.PrimaryServer, nil
 	default:
-		return nil, fmt.Errorf("http status %d unhandled", resp.StatusCode)
+		return nil, &StatusError{StatusCode: resp.StatusCode}
 	}
 }
 
@@ -67,7 +67,7 @@ func (c *Client) GetPrimaryServers(ctx context.Context, zoneID string) ([]Primar
 
 		return response.PrimaryServers, nil
 	default:
-		return nil, fmt.Errorf("http status %d unhandled", resp.StatusCode)
+		return nil, &StatusError{StatusCode: resp.StatusCode}
 	}
 }
 
@@ -88,7 +88,7 @@ func (c *Client) CreatePrimaryServer(ctx context.Context, server CreatePrimarySe
 
 		return &response.PrimaryServer, nil
 	default:
-		return nil, fmt.Errorf("http status %d unhandled", resp.StatusCode)
+		return nil, &StatusError{StatusCode: resp.StatusCode}
 	}
 }
 
@@ -109,7 +109,7 @@ func (c *Client) UpdatePrimaryServer(ctx context.Context, server PrimaryServer)
 
 		return &response.PrimaryServer, nil
 	default:
-		return nil, fmt.Errorf("http status %d unhandled", resp.StatusCode)
+		return nil, &StatusError{StatusCode: resp.StatusCode}
 	}
 }
 
@@ -123,6 +123,6 @@ func (c *Client) DeletePrimaryServer(ctx context.Context, id string) error {
 	case http.StatusOK:
 		return nil
 	default:
-		return fmt.Errorf("http status %d unhandled", resp.StatusCode)
+		return &StatusError{StatusCode: resp.StatusCode}
 	}
 }
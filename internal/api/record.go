@@ -77,7 +77,7 @@ func (c *Client) GetRecordByName(ctx context.Context, zoneID string, name string
 
 		return nil, fmt.Errorf("there are records in zone %s, but %s isn't included", zoneID, name)
 	default:
-		return nil, fmt.Errorf("http status %d unhandled", resp.StatusCode)
+		return nil, &StatusError{StatusCode: resp.StatusCode}
 	}
 }
 
@@ -99,7 +99,7 @@ func (c *Client) GetRecordsByZoneID(ctx context.Context, zoneID string) (*[]Reco
 
 		return &response.Records, nil
 	default:
-		return nil, fmt.Errorf("http status %d unhandled", resp.StatusCode)
+		return nil, &StatusError{StatusCode: resp.StatusCode}
 	}
 }
 
@@ -118,12 +118,12 @@ func (c *Client) GetRecord(ctx context.Context, recordID string) (*Record, error
 
 		err = readAndParseJSONBody(resp, &response)
 		if err != nil {
-			return nil, fmt.Errorf("error Reading json response of get record %s request: %s", recordID, err)
+			return nil, fmt.Errorf("error Reading json response of get record %s request: %w", recordID, err)
 		}
 
 		return &response.Record, nil
 	default:
-		return nil, fmt.Errorf("http status %d unhandled", resp.StatusCode)
+		return nil, &StatusError{StatusCode: resp.StatusCode}
 	}
 }
 
@@ -149,7 +149,7 @@ func (c *Client) CreateRecord(ctx context.Context, opts CreateRecordOpts) (*Reco
 
 		return &response.Record, nil
 	default:
-		return nil, fmt.Errorf("http status %d unhandled", resp.StatusCode)
+		return nil, &StatusError{StatusCode: resp.StatusCode}
 	}
 }
 
@@ -157,14 +157,14 @@ func (c *Client) CreateRecord(ctx context.Context, opts CreateRecordOpts) (*Reco
 func (c *Client) DeleteRecord(ctx context.Context, id string) error {
 	resp, err := c.request(ctx, http.MethodDelete, "/api/v1/records/"+id, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting record %s: %s", id, err)
+		return fmt.Errorf("error deleting record %s: %w", id, err)
 	}
 
 	switch resp.StatusCode {
 	case http.StatusOK:
 		return nil
 	default:
-		return fmt.Errorf("http status %d unhandled", resp.StatusCode)
+		return &StatusError{StatusCode: resp.StatusCode}
 	}
 }
 
@@ -172,7 +172,7 @@ func (c *Client) DeleteRecord(ctx context.Context, id string) error {
 func (c *Client) UpdateRecord(ctx context.Context, record Record) (*Record, error) {
 	resp, err := c.request(ctx, http.MethodPut, "/api/v1/records/"+record.ID, record)
 	if err != nil {
-		return nil, fmt.Errorf("error updating record %s: %s", record.ID, err)
+		return nil, fmt.Errorf("error updating record %s: %w", record.ID, err)
 	}
 
 	switch resp.StatusCode {
@@ -186,6 +186,6 @@ func (c *Client) UpdateRecord(ctx context.Context, record Record) (*Record, erro
 
 		return &response.Record, nil
 	default:
-		return nil, fmt.Errorf("http status %d unhandled", resp.StatusCode)
+		return nil, &StatusError{StatusCode: resp.StatusCode}
 	}
 }
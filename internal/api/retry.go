@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// StatusError is returned for API responses with an HTTP status code the client doesn't handle.
+type StatusError struct {
+	StatusCode int
+	// RetryAfter is the time until the rate limit resets, if the API sent it with a 429 response.
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	if e.StatusCode == http.StatusTooManyRequests {
+		return ErrRateLimited.Error()
+	}
+
+	return fmt.Sprintf("http status %d unhandled", e.StatusCode)
+}
+
+// Is reports a 429 response as ErrRateLimited.
+func (e *StatusError) Is(target error) bool {
+	return target == ErrRateLimited && e.StatusCode == http.StatusTooManyRequests
+}
+
+// IsRetryable reports whether an error returned by the client is transient, so the request may succeed
+// when it is sent again. These are rate limited requests, server errors and network errors. Client
+// errors like an invalid token or an invalid value fail the same way on every attempt.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	var certErr *tls.CertificateVerificationError
+	if errors.As(err, &certErr) {
+		return false
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// IsAmbiguous reports whether a failed request may have been processed by the API anyway, because the
+// server failed or the response got lost or couldn't be read. Rate limited requests and client errors
+// are rejected by the API and are not ambiguous.
+func IsAmbiguous(err error) bool {
+	if err == nil || errors.Is(err, ErrRateLimited) {
+		return false
+	}
+
+	if IsRetryable(err) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+
+	return errors.As(err, &syntaxErr) || errors.As(err, &typeErr)
+}
+
+// RetryAfter returns the time until the rate limit resets for a rate limited request and zero otherwise.
+func RetryAfter(err error) time.Duration {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.RetryAfter
+	}
+
+	return 0
+}
+
+// parseRateLimitReset returns the duration in the ratelimit-reset header, which holds the seconds until the
+// rate limit resets.
+func parseRateLimitReset(header http.Header) time.Duration {
+	seconds, err := strconv.Atoi(header.Get(RateLimitResetHeader))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name       string
+		fault      fake.Fault
+		retryable  bool
+		ambiguous  bool
+		retryAfter time.Duration
+	}{
+		{
+			name:       "rate limited",
+			fault:      fake.Fault{Kind: fake.FaultRateLimit, Reset: 5 * time.Second},
+			retryable:  true,
+			retryAfter: 5 * time.Second,
+		},
+		{
+			name:      "internal server error",
+			fault:     fake.Fault{Kind: fake.FaultErrorResponse},
+			retryable: true,
+			ambiguous: true,
+		},
+		{
+			name:      "bad gateway",
+			fault:     fake.Fault{Kind: fake.FaultErrorResponse, Status: http.StatusBadGateway},
+			retryable: true,
+			ambiguous: true,
+		},
+		{
+			name:      "service unavailable",
+			fault:     fake.Fault{Kind: fake.FaultErrorResponse, Status: http.StatusServiceUnavailable},
+			retryable: true,
+			ambiguous: true,
+		},
+		{
+			name:  "unauthorized",
+			fault: fake.Fault{Kind: fake.FaultErrorResponse, Status: http.StatusUnauthorized},
+		},
+		{
+			name:  "forbidden",
+			fault: fake.Fault{Kind: fake.FaultErrorResponse, Status: http.StatusForbidden},
+		},
+		{
+			name:  "unprocessable entity",
+			fault: fake.Fault{Kind: fake.FaultErrorResponse, Status: http.StatusUnprocessableEntity},
+		},
+		{
+			name:      "dropped connection",
+			fault:     fake.Fault{Kind: fake.FaultDropConnection, Times: 2},
+			retryable: true,
+			ambiguous: true,
+		},
+		{
+			name:      "truncated body",
+			fault:     fake.Fault{Kind: fake.FaultTruncatedBody},
+			retryable: true,
+			ambiguous: true,
+		},
+		{
+			name:      "malformed JSON",
+			fault:     fake.Fault{Kind: fake.FaultMalformedJSON},
+			ambiguous: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, _, zone := createFakeClient(t, tc.fault)
+
+			_, err := client.GetZone(context.Background(), zone.ID)
+			require.Error(t, err)
+			assert.Equal(t, tc.retryable, IsRetryable(err), err)
+			assert.Equal(t, tc.ambiguous, IsAmbiguous(err), err)
+			assert.Equal(t, tc.retryAfter, RetryAfter(err))
+		})
+	}
+}
+
+func TestIsRetryableErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "not found",
+			err:  fmt.Errorf("zone 1: %w", ErrNotFound),
+		},
+		{
+			name: "canceled",
+			err:  fmt.Errorf("error sending request: %w", context.Canceled),
+		},
+		{
+			name: "deadline exceeded",
+			err:  fmt.Errorf("error sending request: %w", context.DeadlineExceeded),
+		},
+		{
+			name:      "wrapped server error",
+			err:       fmt.Errorf("error getting zones: %w", &StatusError{StatusCode: http.StatusGatewayTimeout}),
+			retryable: true,
+		},
+		{
+			name: "unknown error",
+			err:  errors.New("unknown"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.retryable, IsRetryable(tc.err))
+		})
+	}
+}
+
+func TestStatusErrorRateLimited(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("request: %w", &StatusError{StatusCode: http.StatusTooManyRequests})
+	require.ErrorIs(t, err, ErrRateLimited)
+	require.NotErrorIs(t, &StatusError{StatusCode: http.StatusInternalServerError}, ErrRateLimited)
+	assert.Equal(t, "request: rate limit exceeded", err.Error())
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Status describes the usage of the API by the client.
+type Status struct {
+	// Requests is the number of requests sent by the client.
+	Requests int64
+	// RateLimitLimit, RateLimitRemaining and RateLimitReset are the rate limit headers of the last
+	// response which had them, or nil if there was none. RateLimitReset is in seconds.
+	RateLimitLimit     *int64
+	RateLimitRemaining *int64
+	RateLimitReset     *int64
+	// UpdatedAt is the time of the last response with rate limit headers.
+	UpdatedAt time.Time
+}
+
+// Status returns the usage of the API by the client so far.
+func (c *Client) Status() Status {
+	c.statusLock.Lock()
+	defer c.statusLock.Unlock()
+
+	return c.status
+}
+
+// countRequest counts a request sent by the client.
+func (c *Client) countRequest() {
+	c.statusLock.Lock()
+	defer c.statusLock.Unlock()
+
+	c.status.Requests++
+}
+
+// updateRateLimit takes the rate limit from the headers of a response.
+func (c *Client) updateRateLimit(header http.Header) {
+	limit, limitErr := strconv.ParseInt(header.Get(RateLimitLimitHeader), 10, 64)
+	remaining, remainingErr := strconv.ParseInt(header.Get(RateLimitRemainingHeader), 10, 64)
+
+	if limitErr != nil && remainingErr != nil {
+		return
+	}
+
+	c.statusLock.Lock()
+	defer c.statusLock.Unlock()
+
+	c.status.RateLimitLimit = parsedHeader(limit, limitErr)
+	c.status.RateLimitRemaining = parsedHeader(remaining, remainingErr)
+	c.status.RateLimitReset = parsedHeader(strconv.ParseInt(header.Get(RateLimitResetHeader), 10, 64))
+	c.status.UpdatedAt = time.Now()
+}
+
+func parsedHeader(value int64, err error) *int64 {
+	if err != nil {
+		return nil
+	}
+
+	return &value
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientStatus(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	server := fake.NewServer(fakeToken)
+	t.Cleanup(server.Close)
+
+	client, err := New(server.URL, fakeToken, http.DefaultTransport)
+	require.NoError(t, err)
+
+	assert.Equal(t, Status{}, client.Status())
+
+	count, err := client.CountZones(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	for _, name := range []string{"example.com", "example.org"} {
+		_, err = client.CreateZone(ctx, CreateZoneOpts{Name: name, TTL: 3600})
+		require.NoError(t, err)
+	}
+
+	count, err = client.CountZones(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	status := client.Status()
+	assert.Equal(t, int64(4), status.Requests)
+	require.NotNil(t, status.RateLimitLimit)
+	assert.Equal(t, int64(fake.DefaultRateLimit), *status.RateLimitLimit)
+	require.NotNil(t, status.RateLimitRemaining)
+	assert.Less(t, *status.RateLimitRemaining, int64(fake.DefaultRateLimit))
+	assert.NotNil(t, status.RateLimitReset)
+	assert.False(t, status.UpdatedAt.IsZero())
+}
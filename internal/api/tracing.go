@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// TracerName is the name of the OpenTelemetry tracer of the API client.
+const TracerName = "github.com/germanbrew/terraform-provider-hetznerdns/internal/api"
+
+// Attributes of the API request spans in addition to the HTTP semantic conventions.
+const (
+	ZoneIDAttribute             = attribute.Key("hetznerdns.zone_id")
+	RateLimitLimitAttribute     = attribute.Key("hetznerdns.ratelimit.limit")
+	RateLimitRemainingAttribute = attribute.Key("hetznerdns.ratelimit.remaining")
+	RateLimitResetAttribute     = attribute.Key("hetznerdns.ratelimit.reset")
+)
+
+// apiCollections are the path segments of the API which are followed by an ID.
+//
+//nolint:gochecknoglobals
+var apiCollections = map[string]bool{"zones": true, "records": true, "primary_servers": true}
+
+// SetTracerProvider makes the client create a span for every API request with a tracer of the given provider.
+func (c *Client) SetTracerProvider(tracerProvider trace.TracerProvider) {
+	c.tracer = tracerProvider.Tracer(TracerName, trace.WithInstrumentationAttributes())
+}
+
+// startSpan starts the span of an API request, named after the method and the path template.
+func (c *Client) startSpan(ctx context.Context, method, path string, reqBody []byte) (context.Context, trace.Span) {
+	tracer := c.tracer
+	if tracer == nil {
+		tracer = noop.Tracer{}
+	}
+
+	template := pathTemplate(path)
+	attributes := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(method),
+		semconv.URLTemplateKey.String(template),
+		semconv.ServerAddress(c.endPoint.Hostname()),
+	}
+
+	if zoneID := requestZoneID(ctx, path, reqBody); zoneID != "" {
+		attributes = append(attributes, ZoneIDAttribute.String(zoneID))
+	}
+
+	return tracer.Start(ctx, method+" "+template, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+}
+
+// endSpan ends the span of an API request with the status and the rate limit of the response or the error.
+func endSpan(span trace.Span, resp *http.Response, err error) {
+	defer span.End()
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+
+	for key, header := range map[attribute.Key]string{
+		RateLimitLimitAttribute:     RateLimitLimitHeader,
+		RateLimitRemainingAttribute: RateLimitRemainingHeader,
+		RateLimitResetAttribute:     RateLimitResetHeader,
+	} {
+		if value, err := strconv.ParseInt(resp.Header.Get(header), 10, 64); err == nil {
+			span.SetAttributes(key.Int64(value))
+		}
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+}
+
+// pathTemplate returns the path without the query and with the IDs replaced by {id}, e.g. /api/v1/zones/{id}.
+func pathTemplate(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if apiCollections[segments[i-1]] && segments[i] != "" {
+			segments[i] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// requestZoneID returns the ID of the zone a request is made for, taken from the path, the query,
+// the body or the audit info of the context.
+func requestZoneID(ctx context.Context, path string, reqBody []byte) string {
+	path, query, _ := strings.Cut(path, "?")
+
+	if id, ok := strings.CutPrefix(path, "/api/v1/zones/"); ok && id != "" {
+		return id
+	}
+
+	if values, err := url.ParseQuery(query); err == nil && values.Get("zone_id") != "" {
+		return values.Get("zone_id")
+	}
+
+	var body struct {
+		ZoneID string `json:"zone_id"`
+	}
+
+	if len(reqBody) > 0 && json.Unmarshal(reqBody, &body) == nil && body.ZoneID != "" {
+		return body.ZoneID
+	}
+
+	info, _ := ctx.Value(auditInfoKey{}).(AuditInfo)
+
+	return info.ZoneID
+}
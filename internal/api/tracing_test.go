@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+func TestPathTemplate(t *testing.T) {
+	t.Parallel()
+
+	for path, want := range map[string]string{
+		"/api/v1/zones":                     "/api/v1/zones",
+		"/api/v1/zones?name=example.com":    "/api/v1/zones",
+		"/api/v1/zones/abc":                 "/api/v1/zones/{id}",
+		"/api/v1/records?zone_id=abc":       "/api/v1/records",
+		"/api/v1/records/abc":               "/api/v1/records/{id}",
+		"/api/v1/primary_servers/abc":       "/api/v1/primary_servers/{id}",
+		"/api/v1/primary_servers?zone_id=a": "/api/v1/primary_servers",
+	} {
+		assert.Equal(t, want, pathTemplate(path), path)
+	}
+}
+
+func TestClientTracing(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, _, zone := createFakeClient(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	client.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+
+	record, err := client.CreateRecord(ctx, CreateRecordOpts{ZoneID: zone.ID, Type: "A", Name: "www", Value: "192.0.2.1"})
+	require.NoError(t, err)
+
+	err = client.DeleteRecord(WithAuditInfo(ctx, AuditInfo{ZoneID: zone.ID}), record.ID)
+	require.NoError(t, err)
+
+	_, err = client.GetZone(ctx, "unknown")
+	require.Error(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+
+	for i, want := range []struct {
+		name       string
+		attributes []attribute.KeyValue
+		status     codes.Code
+	}{
+		{
+			name: "POST /api/v1/records",
+			attributes: []attribute.KeyValue{
+				semconv.HTTPRequestMethodKey.String(http.MethodPost),
+				semconv.URLTemplateKey.String("/api/v1/records"),
+				ZoneIDAttribute.String(zone.ID),
+				semconv.HTTPResponseStatusCode(http.StatusOK),
+			},
+		},
+		{
+			name: "DELETE /api/v1/records/{id}",
+			attributes: []attribute.KeyValue{
+				semconv.HTTPRequestMethodKey.String(http.MethodDelete),
+				semconv.URLTemplateKey.String("/api/v1/records/{id}"),
+				ZoneIDAttribute.String(zone.ID),
+				semconv.HTTPResponseStatusCode(http.StatusOK),
+			},
+		},
+		{
+			name: "GET /api/v1/zones/{id}",
+			attributes: []attribute.KeyValue{
+				ZoneIDAttribute.String("unknown"),
+				semconv.HTTPResponseStatusCode(http.StatusNotFound),
+			},
+			status: codes.Error,
+		},
+	} {
+		span := spans[i]
+
+		assert.Equal(t, want.name, span.Name)
+		assert.Equal(t, want.status, span.Status.Code)
+		assert.Subset(t, span.Attributes, want.attributes)
+
+		keys := make([]attribute.Key, 0, len(span.Attributes))
+		for _, kv := range span.Attributes {
+			keys = append(keys, kv.Key)
+		}
+
+		assert.Contains(t, keys, RateLimitRemainingAttribute)
+		assert.Contains(t, keys, RateLimitLimitAttribute)
+	}
+}
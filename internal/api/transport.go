@@ -0,0 +1,111 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+var (
+	ErrInvalidCABundle         = errors.New("no certificates found in CA bundle")
+	ErrIncompleteClientKeyPair = errors.New("client certificate and client key must be set together")
+)
+
+// TransportOptions covers the settings of the HTTP transport used to connect to the API.
+type TransportOptions struct {
+	// ProxyURL is the URL of the HTTP proxy. If empty, the proxy is taken from the environment.
+	ProxyURL string
+	// CABundle is a PEM encoded bundle of CA certificates or the path to one, which are trusted
+	// in addition to the system certificates.
+	CABundle string
+	// InsecureSkipVerify disables the verification of the server certificate. Use it for testing only.
+	InsecureSkipVerify bool
+	// ClientCertificate and ClientKey are a PEM encoded client certificate and key or paths to them.
+	ClientCertificate string
+	ClientKey         string
+}
+
+// NewTransport creates an HTTP transport with the given options based on http.DefaultTransport.
+func NewTransport(opts TransportOptions) (*http.Transport, error) {
+	defaultTransport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("unexpected default transport type %T", http.DefaultTransport)
+	}
+
+	transport := defaultTransport.Clone()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: opts.InsecureSkipVerify, //nolint:gosec // explicitly requested for testing
+	}
+
+	if opts.ProxyURL != "" {
+		proxyURL, err := url.Parse(opts.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing proxy URL: %w", err)
+		}
+
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if opts.CABundle != "" {
+		bundle, err := readPEM(opts.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA bundle: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, ErrInvalidCABundle
+		}
+
+		transport.TLSClientConfig.RootCAs = pool
+	}
+
+	if opts.ClientCertificate != "" || opts.ClientKey != "" {
+		if opts.ClientCertificate == "" || opts.ClientKey == "" {
+			return nil, ErrIncompleteClientKeyPair
+		}
+
+		certificate, err := readPEM(opts.ClientCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("error reading client certificate: %w", err)
+		}
+
+		key, err := readPEM(opts.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("error reading client key: %w", err)
+		}
+
+		keyPair, err := tls.X509KeyPair(certificate, key)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %w", err)
+		}
+
+		transport.TLSClientConfig.Certificates = []tls.Certificate{keyPair}
+	}
+
+	return transport, nil
+}
+
+// readPEM returns the given PEM encoded data or reads it from the file at the given path.
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+
+	data, err := os.ReadFile(value)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+
+	return data, nil
+}
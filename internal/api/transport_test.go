@@ -0,0 +1,215 @@
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// generateCertificate creates a self-signed certificate and returns it with its key in PEM encoding.
+func generateCertificate(t *testing.T, usage x509.ExtKeyUsage) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{usage},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func get(t *testing.T, transport http.RoundTripper, uri string) error {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, uri, nil)
+	require.NoError(t, err)
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+func TestNewTransportTLS(t *testing.T) {
+	t.Parallel()
+
+	serverCert, serverKey := generateCertificate(t, x509.ExtKeyUsageServerAuth)
+	clientCert, clientKey := generateCertificate(t, x509.ExtKeyUsageClientAuth)
+
+	serverKeyPair, err := tls.X509KeyPair([]byte(serverCert), []byte(serverKey))
+	require.NoError(t, err)
+
+	clientCAs := x509.NewCertPool()
+	require.True(t, clientCAs.AppendCertsFromPEM([]byte(clientCert)))
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{serverKeyPair},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	mtlsServer := httptest.NewUnstartedServer(server.Config.Handler)
+	mtlsServer.TLS = server.TLS.Clone()
+	mtlsServer.TLS.ClientAuth = tls.RequireAndVerifyClientCert
+	mtlsServer.StartTLS()
+	t.Cleanup(mtlsServer.Close)
+
+	dir := t.TempDir()
+	caBundlePath := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(caBundlePath, []byte(serverCert), 0o600))
+
+	clientCertPath := filepath.Join(dir, "client.pem")
+	require.NoError(t, os.WriteFile(clientCertPath, []byte(clientCert), 0o600))
+
+	clientKeyPath := filepath.Join(dir, "client-key.pem")
+	require.NoError(t, os.WriteFile(clientKeyPath, []byte(clientKey), 0o600))
+
+	for _, tc := range []struct {
+		name        string
+		opts        TransportOptions
+		mtls        bool
+		errIs       error
+		errContains string
+	}{
+		{
+			name:        "untrusted server certificate",
+			opts:        TransportOptions{},
+			errContains: "certificate signed by unknown authority",
+		},
+		{
+			name: "CA bundle",
+			opts: TransportOptions{CABundle: serverCert},
+		},
+		{
+			name: "CA bundle file",
+			opts: TransportOptions{CABundle: caBundlePath},
+		},
+		{
+			name:        "CA bundle file not found",
+			opts:        TransportOptions{CABundle: filepath.Join(dir, "missing.pem")},
+			errContains: "error reading CA bundle",
+		},
+		{
+			name:  "CA bundle without certificates",
+			opts:  TransportOptions{CABundle: "-----BEGIN NOTHING-----"},
+			errIs: ErrInvalidCABundle,
+		},
+		{
+			name: "insecure skip verify",
+			opts: TransportOptions{InsecureSkipVerify: true},
+		},
+		{
+			name:        "client certificate required",
+			opts:        TransportOptions{CABundle: serverCert},
+			mtls:        true,
+			errContains: "certificate required",
+		},
+		{
+			name: "client certificate",
+			opts: TransportOptions{CABundle: serverCert, ClientCertificate: clientCert, ClientKey: clientKey},
+			mtls: true,
+		},
+		{
+			name: "client certificate files",
+			opts: TransportOptions{CABundle: caBundlePath, ClientCertificate: clientCertPath, ClientKey: clientKeyPath},
+			mtls: true,
+		},
+		{
+			name:  "client certificate without key",
+			opts:  TransportOptions{ClientCertificate: clientCert},
+			errIs: ErrIncompleteClientKeyPair,
+		},
+		{
+			name:        "client key not matching certificate",
+			opts:        TransportOptions{ClientCertificate: clientCert, ClientKey: serverKey},
+			errContains: "error loading client certificate",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			uri := server.URL
+			if tc.mtls {
+				uri = mtlsServer.URL
+			}
+
+			transport, err := NewTransport(tc.opts)
+			if err == nil {
+				err = get(t, transport, uri)
+			}
+
+			switch {
+			case tc.errIs != nil:
+				require.ErrorIs(t, err, tc.errIs)
+			case tc.errContains != "":
+				require.ErrorContains(t, err, tc.errContains)
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewTransportProxy(t *testing.T) {
+	t.Parallel()
+
+	var proxied string
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	transport, err := NewTransport(TransportOptions{ProxyURL: proxy.URL})
+	require.NoError(t, err)
+
+	require.NoError(t, get(t, transport, "http://dns.example.com/api/v1/zones"))
+	assert.Equal(t, "http://dns.example.com/api/v1/zones", proxied)
+
+	_, err = NewTransport(TransportOptions{ProxyURL: "://invalid"})
+	require.ErrorContains(t, err, "error parsing proxy URL")
+}
@@ -12,14 +12,14 @@ func parseUnprocessableEntityError(resp *http.Response) (*UnprocessableEntityErr
 	defer resp.Body.Close()
 
 	if err != nil {
-		return nil, fmt.Errorf("error reading HTTP response body: %e", err)
+		return nil, fmt.Errorf("error reading HTTP response body: %w", err)
 	}
 
 	var unprocessableEntityError UnprocessableEntityError
 
 	err = json.Unmarshal(body, &unprocessableEntityError)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing JSON response body: %e", err)
+		return nil, fmt.Errorf("error parsing JSON response body: %w", err)
 	}
 
 	return &unprocessableEntityError, nil
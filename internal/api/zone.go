@@ -45,6 +45,17 @@ type ZoneResponse struct {
 // GetZones represents the content of a GET Zones response.
 type GetZones struct {
 	Zones []Zone `json:"zones"`
+	Meta  Meta   `json:"meta"`
+}
+
+// Meta represents the metadata of a paginated response.
+type Meta struct {
+	Pagination Pagination `json:"pagination"`
+}
+
+// Pagination represents the pagination of a response.
+type Pagination struct {
+	TotalEntries int64 `json:"total_entries"`
 }
 
 // GetZonesByNameResponse represents the content of a GET Zones response.
@@ -73,7 +84,36 @@ func (c *Client) GetZones(ctx context.Context) ([]Zone, error) {
 
 		return response.Zones, nil
 	default:
-		return nil, fmt.Errorf("error getting zones. HTTP status %d unhandled", resp.StatusCode)
+		return nil, fmt.Errorf("error getting zones: %w", &StatusError{StatusCode: resp.StatusCode})
+	}
+}
+
+// CountZones returns the number of zones the API token has access to.
+func (c *Client) CountZones(ctx context.Context) (int64, error) {
+	resp, err := c.request(ctx, http.MethodGet, "/api/v1/zones?per_page=1", nil)
+	if err != nil {
+		return 0, fmt.Errorf("error counting zones: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		// Undocumented API behavior: Hetzner DNS API returns 404 when there are no zones
+		resp.Body.Close()
+
+		return 0, nil
+	case http.StatusOK:
+		var response GetZones
+
+		err = readAndParseJSONBody(resp, &response)
+		if err != nil {
+			return 0, err
+		}
+
+		return response.Meta.Pagination.TotalEntries, nil
+	default:
+		resp.Body.Close()
+
+		return 0, fmt.Errorf("error counting zones: %w", &StatusError{StatusCode: resp.StatusCode})
 	}
 }
 
@@ -97,7 +137,7 @@ func (c *Client) GetZone(ctx context.Context, id string) (*Zone, error) {
 
 		return &response.Zone, nil
 	default:
-		return nil, fmt.Errorf("http status %d unhandled", resp.StatusCode)
+		return nil, &StatusError{StatusCode: resp.StatusCode}
 	}
 }
 
@@ -105,7 +145,7 @@ func (c *Client) GetZone(ctx context.Context, id string) (*Zone, error) {
 func (c *Client) UpdateZone(ctx context.Context, zone Zone) (*Zone, error) {
 	resp, err := c.request(ctx, http.MethodPut, "/api/v1/zones/"+zone.ID, zone)
 	if err != nil {
-		return nil, fmt.Errorf("error updating zone %s: %s", zone.ID, err)
+		return nil, fmt.Errorf("error updating zone %s: %w", zone.ID, err)
 	}
 
 	switch resp.StatusCode {
@@ -119,7 +159,7 @@ func (c *Client) UpdateZone(ctx context.Context, zone Zone) (*Zone, error) {
 
 		return &response.Zone, nil
 	default:
-		return nil, fmt.Errorf("http status %d unhandled", resp.StatusCode)
+		return nil, &StatusError{StatusCode: resp.StatusCode}
 	}
 }
 
@@ -127,14 +167,14 @@ func (c *Client) UpdateZone(ctx context.Context, zone Zone) (*Zone, error) {
 func (c *Client) DeleteZone(ctx context.Context, id string) error {
 	resp, err := c.request(ctx, http.MethodDelete, "/api/v1/zones/"+id, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting zone %s: %s", id, err)
+		return fmt.Errorf("error deleting zone %s: %w", id, err)
 	}
 
 	switch resp.StatusCode {
 	case http.StatusOK:
 		return nil
 	default:
-		return fmt.Errorf("http status %d unhandled", resp.StatusCode)
+		return &StatusError{StatusCode: resp.StatusCode}
 	}
 }
 
@@ -162,7 +202,7 @@ func (c *Client) GetZoneByName(ctx context.Context, name string) (*Zone, error)
 
 		return &response.Zones[0], nil
 	default:
-		return nil, fmt.Errorf("error getting zones. HTTP status %d unhandled", resp.StatusCode)
+		return nil, fmt.Errorf("error getting zones: %w", &StatusError{StatusCode: resp.StatusCode})
 	}
 }
 
@@ -190,6 +230,6 @@ func (c *Client) CreateZone(ctx context.Context, opts CreateZoneOpts) (*Zone, er
 
 		return &response.Zone, nil
 	default:
-		return nil, fmt.Errorf("http status %d unhandled", resp.StatusCode)
+		return nil, &StatusError{StatusCode: resp.StatusCode}
 	}
 }
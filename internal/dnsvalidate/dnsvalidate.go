@@ -0,0 +1,439 @@
+// Package dnsvalidate validates the values of DNS records of the types supported by Hetzner DNS.
+//
+// https://docs.hetzner.com/dns-console/dns/general/supported-dns-record-types/
+package dnsvalidate
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidValue = errors.New("invalid record value")
+
+// maxCharacterStringLength is the maximum length of a single character-string in bytes.
+//
+// https://datatracker.ietf.org/doc/html/rfc1035#section-3.3
+const maxCharacterStringLength = 255
+
+// validators maps record types to the validator of their values.
+//
+//nolint:gochecknoglobals
+var validators = map[string]func(string) error{
+	"A":     A,
+	"AAAA":  AAAA,
+	"CAA":   CAA,
+	"CNAME": Hostname,
+	"DANE":  TLSA,
+	"DS":    DS,
+	"HINFO": HINFO,
+	"MX":    MX,
+	"NS":    Hostname,
+	"PTR":   Hostname,
+	"RP":    RP,
+	"SOA":   SOA,
+	"SRV":   SRV,
+	"TLSA":  TLSA,
+	"TXT":   TXT,
+}
+
+// Validate checks the value of a record of the given type.
+// Values of unknown record types are left to the API to validate.
+func Validate(recordType, value string) error {
+	validate, ok := validators[strings.ToUpper(recordType)]
+	if !ok {
+		return nil
+	}
+
+	return validate(value)
+}
+
+// A checks if the value is an IPv4 address.
+func A(value string) error {
+	ip := net.ParseIP(value)
+	if ip == nil || ip.To4() == nil || strings.Contains(value, ":") {
+		return fmt.Errorf("%w: %q is not an IPv4 address", ErrInvalidValue, value)
+	}
+
+	return nil
+}
+
+// AAAA checks if the value is an IPv6 address.
+func AAAA(value string) error {
+	ip := net.ParseIP(value)
+	if ip == nil || !strings.Contains(value, ":") {
+		return fmt.Errorf("%w: %q is not an IPv6 address", ErrInvalidValue, value)
+	}
+
+	return nil
+}
+
+// Hostname checks if the value is a host name as used by CNAME, NS and PTR records.
+// Names can be relative to the zone, fully qualified with a trailing dot or "@" for the zone apex.
+// Labels may contain any printable character except whitespace, e.g. "0/26" for classless reverse
+// delegation (RFC 2317), but URLs are rejected.
+func Hostname(value string) error {
+	if value == "@" {
+		return nil
+	}
+
+	if strings.Contains(value, "://") {
+		return fmt.Errorf("%w: %q is a URL, not a host name", ErrInvalidValue, value)
+	}
+
+	name := strings.TrimSuffix(value, ".")
+	if name == "" || len(name) > 253 {
+		return fmt.Errorf("%w: %q is not a valid host name", ErrInvalidValue, value)
+	}
+
+	for label := range strings.SplitSeq(name, ".") {
+		if label == "" || len(label) > 63 {
+			return fmt.Errorf("%w: %q contains an empty or too long label", ErrInvalidValue, value)
+		}
+
+		for _, c := range label {
+			if !isLabelChar(c) {
+				return fmt.Errorf("%w: %q contains the invalid character %q", ErrInvalidValue, value, c)
+			}
+		}
+	}
+
+	return nil
+}
+
+// MX checks if the value consists of a preference and a mail exchange host name (e.g. "10 mail.example.com.").
+// The host "." is the null MX of a domain which doesn't accept mail (RFC7505).
+func MX(value string) error {
+	fields, err := expectFields(value, "MX", "<preference> <host>", 2)
+	if err != nil {
+		return err
+	}
+
+	if err = checkUint(fields[0], "preference", 16); err != nil {
+		return err
+	}
+
+	if fields[1] == "." {
+		return nil
+	}
+
+	return Hostname(fields[1])
+}
+
+// SRV checks if the value consists of priority, weight, port and target (e.g. "10 0 389 ldap.example.com.").
+// The target "." means that the service is not available.
+func SRV(value string) error {
+	fields, err := expectFields(value, "SRV", "<priority> <weight> <port> <target>", 4)
+	if err != nil {
+		return err
+	}
+
+	for i, name := range []string{"priority", "weight", "port"} {
+		if err = checkUint(fields[i], name, 16); err != nil {
+			return err
+		}
+	}
+
+	if fields[3] == "." {
+		return nil
+	}
+
+	return Hostname(fields[3])
+}
+
+// CAA checks if the value consists of flags, tag and value (e.g. `0 issue "letsencrypt.org"`).
+//
+// https://datatracker.ietf.org/doc/html/rfc8659#section-4.1
+func CAA(value string) error {
+	parts := strings.SplitN(strings.TrimSpace(value), " ", 3)
+	if len(parts) != 3 {
+		return fmt.Errorf("%w: CAA value %q must have the format <flags> <tag> <value>", ErrInvalidValue, value)
+	}
+
+	if err := checkUint(parts[0], "flags", 8); err != nil {
+		return err
+	}
+
+	tag := parts[1]
+	if tag == "" || len(tag) > 15 {
+		return fmt.Errorf("%w: CAA tag %q must be between 1 and 15 characters long", ErrInvalidValue, tag)
+	}
+
+	for _, c := range tag {
+		if !isAlphanumeric(c) {
+			return fmt.Errorf("%w: CAA tag %q must only contain letters and digits", ErrInvalidValue, tag)
+		}
+	}
+
+	strs, err := characterStrings(parts[2])
+	if err != nil {
+		return err
+	}
+
+	if len(strs) != 1 {
+		return fmt.Errorf("%w: CAA value %q must be a single string, quote it if it contains spaces", ErrInvalidValue, parts[2])
+	}
+
+	if strings.EqualFold(tag, "iodef") && !strings.HasPrefix(strs[0], "mailto:") &&
+		!strings.HasPrefix(strs[0], "http://") && !strings.HasPrefix(strs[0], "https://") {
+		return fmt.Errorf("%w: CAA iodef value %q must be a mailto:, http:// or https:// URL", ErrInvalidValue, strs[0])
+	}
+
+	return nil
+}
+
+// TLSA checks if the value consists of certificate usage, selector, matching type and
+// certificate association data (e.g. "3 1 1 <sha256 hex>"). It's used for TLSA and DANE records.
+//
+// https://datatracker.ietf.org/doc/html/rfc6698#section-2.1
+func TLSA(value string) error {
+	fields := strings.Fields(value)
+	if len(fields) < 4 {
+		return fmt.Errorf("%w: TLSA value %q must have the format <usage> <selector> <matching type> <data>", ErrInvalidValue, value)
+	}
+
+	for i, field := range []struct {
+		name string
+		max  uint64
+	}{
+		{name: "certificate usage", max: 3},
+		{name: "selector", max: 1},
+		{name: "matching type", max: 2},
+	} {
+		if err := checkUintMax(fields[i], field.name, field.max); err != nil {
+			return err
+		}
+	}
+
+	// Matching types: 0 = full certificate, 1 = SHA-256, 2 = SHA-512
+	digestLengths := map[string]int{"1": 64, "2": 128}
+
+	return checkHex(strings.Join(fields[3:], ""), "certificate association data", digestLengths[fields[2]])
+}
+
+// DS checks if the value consists of key tag, algorithm, digest type and digest (e.g. "2371 13 2 <sha256 hex>").
+//
+// https://datatracker.ietf.org/doc/html/rfc4034#section-5.1
+func DS(value string) error {
+	fields := strings.Fields(value)
+	if len(fields) < 4 {
+		return fmt.Errorf("%w: DS value %q must have the format <key tag> <algorithm> <digest type> <digest>", ErrInvalidValue, value)
+	}
+
+	if err := checkUint(fields[0], "key tag", 16); err != nil {
+		return err
+	}
+
+	if err := checkUint(fields[1], "algorithm", 8); err != nil {
+		return err
+	}
+
+	// Digest types: 1 = SHA-1, 2 = SHA-256, 3 = GOST R 34.11-94, 4 = SHA-384
+	digestLengths := map[string]int{"1": 40, "2": 64, "3": 64, "4": 96}
+
+	digestLength, ok := digestLengths[fields[2]]
+	if !ok {
+		return fmt.Errorf("%w: DS digest type %q must be one of 1, 2, 3 or 4", ErrInvalidValue, fields[2])
+	}
+
+	return checkHex(strings.Join(fields[3:], ""), "digest", digestLength)
+}
+
+// RP checks if the value consists of the mailbox and the name of a TXT record with further information.
+// Both can be "." if they are not available.
+//
+// https://datatracker.ietf.org/doc/html/rfc1183#section-2.2
+func RP(value string) error {
+	fields, err := expectFields(value, "RP", "<mailbox> <txt record name>", 2)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		if field == "." {
+			continue
+		}
+
+		if err = Hostname(field); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HINFO checks if the value consists of two character-strings for CPU and operating system (e.g. `"amd64" "linux"`).
+func HINFO(value string) error {
+	strs, err := characterStrings(value)
+	if err != nil {
+		return err
+	}
+
+	if len(strs) != 2 {
+		return fmt.Errorf("%w: HINFO value %q must consist of two strings <cpu> <os>, quote them if they contain spaces", ErrInvalidValue, value)
+	}
+
+	return nil
+}
+
+// TXT checks if every character-string of the value is at most 255 bytes long. A value which isn't
+// made up of quoted character-strings is a single character-string.
+func TXT(value string) error {
+	strs, err := characterStrings(value)
+	if err != nil || !strings.HasPrefix(strings.TrimSpace(value), `"`) {
+		strs = []string{value}
+	}
+
+	for _, str := range strs {
+		if len(str) > maxCharacterStringLength {
+			return fmt.Errorf("%w: TXT strings must not be longer than %d bytes, split it into multiple quoted strings or "+
+				"enable the TXT formatter of the provider", ErrInvalidValue, maxCharacterStringLength)
+		}
+	}
+
+	return nil
+}
+
+// SOA checks if the value consists of primary name server, responsible mailbox, serial, refresh, retry, expire and minimum TTL.
+func SOA(value string) error {
+	fields, err := expectFields(value, "SOA", "<mname> <rname> <serial> <refresh> <retry> <expire> <minimum>", 7)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields[:2] {
+		if err = Hostname(field); err != nil {
+			return err
+		}
+	}
+
+	for i, name := range []string{"serial", "refresh", "retry", "expire", "minimum"} {
+		if err = checkUint(fields[i+2], name, 32); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func expectFields(value, recordType, format string, count int) ([]string, error) {
+	fields := strings.Fields(value)
+	if len(fields) != count {
+		return nil, fmt.Errorf("%w: %s value %q must have the format %s", ErrInvalidValue, recordType, value, format)
+	}
+
+	return fields, nil
+}
+
+func checkUint(value, name string, bitSize int) error {
+	if _, err := strconv.ParseUint(value, 10, bitSize); err != nil {
+		return fmt.Errorf("%w: %s %q must be an unsigned %d bit integer", ErrInvalidValue, name, value, bitSize)
+	}
+
+	return nil
+}
+
+func checkUintMax(value, name string, maximum uint64) error {
+	v, err := strconv.ParseUint(value, 10, 8)
+	if err != nil || v > maximum {
+		return fmt.Errorf("%w: %s %q must be an integer between 0 and %d", ErrInvalidValue, name, value, maximum)
+	}
+
+	return nil
+}
+
+// checkHex checks if the value is a hex string. A length of 0 accepts any non-empty even length.
+func checkHex(value, name string, length int) error {
+	if value == "" || len(value)%2 != 0 {
+		return fmt.Errorf("%w: %s must be a hex string with an even number of characters", ErrInvalidValue, name)
+	}
+
+	for _, c := range value {
+		if !isHex(c) {
+			return fmt.Errorf("%w: %s contains the non hex character %q", ErrInvalidValue, name, c)
+		}
+	}
+
+	if length != 0 && len(value) != length {
+		return fmt.Errorf("%w: %s must be %d hex characters long, got %d", ErrInvalidValue, name, length, len(value))
+	}
+
+	return nil
+}
+
+// characterStrings splits a value into its character-strings. Character-strings are either
+// quoted or separated by whitespace, backslash escapes are only allowed in quoted strings.
+func characterStrings(value string) ([]string, error) {
+	var (
+		strs    []string
+		current strings.Builder
+		quoted  bool
+		escaped bool
+		inStr   bool
+	)
+
+	for _, c := range value {
+		switch {
+		case escaped:
+			current.WriteRune(c)
+
+			escaped = false
+		case quoted && c == '\\':
+			escaped = true
+		case c == '"':
+			if quoted {
+				strs = append(strs, current.String())
+				current.Reset()
+			} else if inStr {
+				return nil, fmt.Errorf("%w: unexpected quote in %q", ErrInvalidValue, value)
+			}
+
+			quoted = !quoted
+			inStr = false
+		case quoted:
+			current.WriteRune(c)
+		case c == ' ' || c == '\t':
+			if inStr {
+				strs = append(strs, current.String())
+				current.Reset()
+
+				inStr = false
+			}
+		default:
+			current.WriteRune(c)
+
+			inStr = true
+		}
+	}
+
+	if quoted || escaped {
+		return nil, fmt.Errorf("%w: unterminated quoted string in %q", ErrInvalidValue, value)
+	}
+
+	if inStr {
+		strs = append(strs, current.String())
+	}
+
+	for _, str := range strs {
+		if len(str) > maxCharacterStringLength {
+			return nil, fmt.Errorf("%w: strings must not be longer than %d bytes", ErrInvalidValue, maxCharacterStringLength)
+		}
+	}
+
+	return strs, nil
+}
+
+func isLabelChar(c rune) bool {
+	return unicode.IsPrint(c) && !unicode.IsSpace(c)
+}
+
+func isAlphanumeric(c rune) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func isHex(c rune) bool {
+	return (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,90 @@
+package dnsvalidate_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/dnsvalidate"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	sha256 := strings.Repeat("ab", 32)
+
+	for _, tc := range []struct {
+		name       string
+		recordType string
+		value      string
+		isValid    bool
+	}{
+		{name: "A", recordType: "A", value: "192.168.1.1", isValid: true},
+		{name: "A with IPv6", recordType: "A", value: "2001:db8::1", isValid: false},
+		{name: "A with IPv4-mapped IPv6", recordType: "A", value: "::ffff:192.168.1.1", isValid: false},
+		{name: "A invalid", recordType: "A", value: "9.9.9.999", isValid: false},
+		{name: "AAAA", recordType: "AAAA", value: "2001:db8::1", isValid: true},
+		{name: "AAAA with IPv4", recordType: "AAAA", value: "192.168.1.1", isValid: false},
+		{name: "CNAME fqdn", recordType: "CNAME", value: "www.example.com.", isValid: true},
+		{name: "CNAME relative", recordType: "CNAME", value: "www", isValid: true},
+		{name: "CNAME apex", recordType: "CNAME", value: "@", isValid: true},
+		{name: "CNAME with space", recordType: "CNAME", value: "www example", isValid: false},
+		{name: "CNAME classless reverse delegation", recordType: "CNAME", value: "1.0/26.2.0.192.in-addr.arpa.", isValid: true},
+		{name: "CNAME empty label", recordType: "CNAME", value: "www..example.com.", isValid: false},
+		{name: "NS", recordType: "NS", value: "hydrogen.ns.hetzner.com.", isValid: true},
+		{name: "NS with URL", recordType: "NS", value: "https://ns.example.com", isValid: false},
+		{name: "PTR", recordType: "PTR", value: "host.example.com.", isValid: true},
+		{name: "MX", recordType: "MX", value: "10 mail.example.com.", isValid: true},
+		{name: "null MX", recordType: "MX", value: "0 .", isValid: true},
+		{name: "MX without preference", recordType: "MX", value: "mail.example.com.", isValid: false},
+		{name: "MX with preference out of range", recordType: "MX", value: "65536 mail.example.com.", isValid: false},
+		{name: "SRV", recordType: "SRV", value: "10 0 389 ldap.example.com.", isValid: true},
+		{name: "SRV unavailable", recordType: "SRV", value: "0 0 0 .", isValid: true},
+		{name: "SRV missing weight", recordType: "SRV", value: "10 389 ldap.example.com.", isValid: false},
+		{name: "SRV port out of range", recordType: "SRV", value: "10 0 70000 ldap.example.com.", isValid: false},
+		{name: "CAA quoted", recordType: "CAA", value: `0 issue "letsencrypt.org"`, isValid: true},
+		{name: "CAA unquoted", recordType: "CAA", value: `0 issuewild letsencrypt.org`, isValid: true},
+		{name: "CAA critical", recordType: "CAA", value: `128 issue ";"`, isValid: true},
+		{name: "CAA iodef", recordType: "CAA", value: `0 iodef "mailto:security@example.com"`, isValid: true},
+		{name: "CAA iodef no URL", recordType: "CAA", value: `0 iodef "security@example.com"`, isValid: false},
+		{name: "CAA flags out of range", recordType: "CAA", value: `256 issue "letsencrypt.org"`, isValid: false},
+		{name: "CAA invalid tag", recordType: "CAA", value: `0 is-sue "letsencrypt.org"`, isValid: false},
+		{name: "CAA unquoted with space", recordType: "CAA", value: `0 issue lets encrypt`, isValid: false},
+		{name: "CAA missing value", recordType: "CAA", value: `0 issue`, isValid: false},
+		{name: "TLSA SHA-256", recordType: "TLSA", value: "3 1 1 " + sha256, isValid: true},
+		{name: "TLSA full certificate", recordType: "TLSA", value: "3 0 0 308201", isValid: true},
+		{name: "TLSA SHA-256 too short", recordType: "TLSA", value: "3 1 1 abcd", isValid: false},
+		{name: "TLSA invalid usage", recordType: "TLSA", value: "4 1 1 " + sha256, isValid: false},
+		{name: "TLSA not hex", recordType: "TLSA", value: "3 1 1 " + strings.Repeat("zz", 32), isValid: false},
+		{name: "DANE", recordType: "DANE", value: "3 1 1 " + sha256, isValid: true},
+		{name: "DS SHA-256", recordType: "DS", value: "2371 13 2 " + sha256, isValid: true},
+		{name: "DS SHA-256 split", recordType: "DS", value: "2371 13 2 " + sha256[:32] + " " + sha256[32:], isValid: true},
+		{name: "DS SHA-1 with SHA-256 digest", recordType: "DS", value: "2371 13 1 " + sha256, isValid: false},
+		{name: "DS unknown digest type", recordType: "DS", value: "2371 13 9 " + sha256, isValid: false},
+		{name: "RP", recordType: "RP", value: "admin.example.com. info.example.com.", isValid: true},
+		{name: "RP without txt", recordType: "RP", value: "admin.example.com. .", isValid: true},
+		{name: "RP single field", recordType: "RP", value: "admin.example.com.", isValid: false},
+		{name: "HINFO", recordType: "HINFO", value: `"amd64" "linux"`, isValid: true},
+		{name: "HINFO unquoted", recordType: "HINFO", value: `amd64 linux`, isValid: true},
+		{name: "HINFO single string", recordType: "HINFO", value: `"amd64 linux"`, isValid: false},
+		{name: "TXT", recordType: "TXT", value: "v=spf1 -all", isValid: true},
+		{name: "TXT too long", recordType: "TXT", value: strings.Repeat("a", 256), isValid: false},
+		{name: "TXT chunked", recordType: "TXT", value: `"` + strings.Repeat("a", 255) + `" "b"`, isValid: true},
+		{name: "TXT chunk too long", recordType: "TXT", value: `"` + strings.Repeat("a", 256) + `" "b"`, isValid: false},
+		{name: "SOA", recordType: "SOA", value: "hydrogen.ns.hetzner.com. dns.hetzner.com. 2024010101 86400 10800 3600000 3600", isValid: true},
+		{name: "SOA missing field", recordType: "SOA", value: "hydrogen.ns.hetzner.com. dns.hetzner.com. 2024010101 86400 10800 3600000", isValid: false},
+		{name: "lower case type", recordType: "mx", value: "10 mail.example.com.", isValid: true},
+		{name: "unknown type", recordType: "UNKNOWN", value: "anything", isValid: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := dnsvalidate.Validate(tc.recordType, tc.value)
+			if tc.isValid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, dnsvalidate.ErrInvalidValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// Package policy checks planned records and zones against the rules a provider configuration allows,
+// e.g. to keep the records of teams sharing a zone apart.
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/utils"
+)
+
+var (
+	ErrViolation     = errors.New("policy violation")
+	ErrInvalidPolicy = errors.New("invalid policy")
+	ErrProtected     = errors.New("protected record")
+)
+
+// Policy holds the rules for records and zones. The zero value allows everything.
+type Policy struct {
+	// AllowedRecordTypes are the record types which may be used. All types are allowed if it is empty.
+	AllowedRecordTypes []string
+	// AllowedNamePatterns are glob patterns of which a record name relative to its zone must match one,
+	// e.g. team-a-*. All names are allowed if it is empty.
+	AllowedNamePatterns []string
+	// DenyWildcardRecords rejects records with a wildcard label, e.g. * or *.dev.
+	DenyWildcardRecords bool
+	// DenyPrivateAddresses rejects A and AAAA records pointing at private addresses (RFC1918 and RFC4193).
+	DenyPrivateAddresses bool
+	// ValidateIPAddresses rejects A and AAAA records whose value isn't an IP address.
+	ValidateIPAddresses bool
+	// MinTTL and MaxTTL are the bounds of the TTL of records and zones. Zero values are not checked.
+	MinTTL int64
+	MaxTTL int64
+	// ProtectedRecords are the records which must not be changed or deleted without an explicit override.
+	ProtectedRecords []ProtectedRecord
+}
+
+// ProtectedRecord is a glob pattern of records which must not be changed or deleted, e.g. the MX records of a zone apex.
+// Empty fields match everything.
+type ProtectedRecord struct {
+	// Zone is a pattern of the zone name, e.g. example.*.
+	Zone string
+	// Name is a pattern of the record name relative to its zone, with @ for the zone apex, e.g. _dmarc.
+	Name string
+	// Type is a pattern of the record type, e.g. MX.
+	Type string
+}
+
+// Validate checks that the patterns of the protected record are well-formed.
+func (r ProtectedRecord) Validate() error {
+	for _, pattern := range []string{r.Zone, r.Name, r.Type} {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("%w: protected record pattern %q: %w", ErrInvalidPolicy, pattern, err)
+		}
+	}
+
+	return nil
+}
+
+// Matches reports whether the record of the zone matches all patterns of the protected record.
+// Names and types are matched case-insensitively and zone names without trailing dot.
+func (r ProtectedRecord) Matches(zone, name, recordType string) bool {
+	return matchPattern(r.Zone, strings.TrimSuffix(zone, ".")) && matchPattern(r.Name, name) && matchPattern(r.Type, recordType)
+}
+
+// String describes the patterns of the protected record.
+func (r ProtectedRecord) String() string {
+	fields := make([]string, 0, 3)
+
+	for _, field := range []struct{ name, pattern string }{{"zone", r.Zone}, {"name", r.Name}, {"type", r.Type}} {
+		if field.pattern != "" {
+			fields = append(fields, fmt.Sprintf("%s=%q", field.name, field.pattern))
+		}
+	}
+
+	return "{" + strings.Join(fields, " ") + "}"
+}
+
+func matchPattern(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+
+	matched, _ := path.Match(strings.ToLower(pattern), strings.ToLower(value))
+
+	return matched
+}
+
+// Validate checks that the rules of the policy are well-formed.
+func (p Policy) Validate() error {
+	for _, pattern := range p.AllowedNamePatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("%w: name pattern %q: %w", ErrInvalidPolicy, pattern, err)
+		}
+	}
+
+	for _, recordType := range p.AllowedRecordTypes {
+		if recordType == "" {
+			return fmt.Errorf("%w: empty record type", ErrInvalidPolicy)
+		}
+	}
+
+	for _, record := range p.ProtectedRecords {
+		if err := record.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CheckRecordType checks that the record type is allowed.
+func (p Policy) CheckRecordType(recordType string) error {
+	if len(p.AllowedRecordTypes) == 0 || slices.ContainsFunc(p.AllowedRecordTypes, func(allowed string) bool {
+		return strings.EqualFold(allowed, recordType)
+	}) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: the record type %s is not allowed, allowed types are %s",
+		ErrViolation, recordType, strings.Join(p.AllowedRecordTypes, ", "))
+}
+
+// CheckRecordName checks the name of a record relative to its zone, with @ for the zone apex.
+func (p Policy) CheckRecordName(name string) error {
+	name = strings.ToLower(name)
+
+	if p.DenyWildcardRecords && slices.Contains(strings.Split(name, "."), "*") {
+		return fmt.Errorf("%w: the wildcard record %q is not allowed", ErrViolation, name)
+	}
+
+	if len(p.AllowedNamePatterns) == 0 {
+		return nil
+	}
+
+	for _, pattern := range p.AllowedNamePatterns {
+		if matched, _ := path.Match(strings.ToLower(pattern), name); matched {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: the record name %q doesn't match any of the allowed name patterns %s",
+		ErrViolation, name, strings.Join(p.AllowedNamePatterns, ", "))
+}
+
+// CheckRecordValue checks the value of a record of the given type.
+func (p Policy) CheckRecordValue(recordType, value string) error {
+	recordType = strings.ToUpper(recordType)
+	if recordType != "A" && recordType != "AAAA" {
+		return nil
+	}
+
+	if p.ValidateIPAddresses {
+		if err := utils.CheckIPAddress(value); err != nil {
+			return err //nolint:wrapcheck // The error describes the invalid value and is not a policy violation.
+		}
+	}
+
+	if !p.DenyPrivateAddresses {
+		return nil
+	}
+
+	if addr, err := netip.ParseAddr(value); err == nil && addr.Unmap().IsPrivate() {
+		return fmt.Errorf("%w: the %s record points at the private address %s", ErrViolation, recordType, value)
+	}
+
+	return nil
+}
+
+// CheckTTL checks the TTL of a record or zone against the bounds of the policy.
+func (p Policy) CheckTTL(ttl int64) error {
+	switch {
+	case ttl < p.MinTTL:
+		return fmt.Errorf("%w: the TTL %d is less than the min_ttl %d", ErrViolation, ttl, p.MinTTL)
+	case p.MaxTTL > 0 && ttl > p.MaxTTL:
+		return fmt.Errorf("%w: the TTL %d is greater than the max_ttl %d", ErrViolation, ttl, p.MaxTTL)
+	default:
+		return nil
+	}
+}
+
+// CheckProtectedRecord checks that the record of the zone doesn't match any of the protected records,
+// with its name relative to the zone and @ for the zone apex.
+func (p Policy) CheckProtectedRecord(zone, name, recordType string) error {
+	for _, record := range p.ProtectedRecords {
+		if record.Matches(zone, name, recordType) {
+			return fmt.Errorf("%w: the %s record %q of zone %s matches the protected record %s", ErrProtected, recordType, name, zone, record)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package policy_test
+
+import (
+	"testing"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/policy"
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyValidate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		policy  policy.Policy
+		isValid bool
+	}{
+		{name: "empty", isValid: true},
+		{name: "patterns", policy: policy.Policy{AllowedNamePatterns: []string{"team-a-*", "api?"}}, isValid: true},
+		{name: "invalid pattern", policy: policy.Policy{AllowedNamePatterns: []string{"team-[a"}}},
+		{name: "empty record type", policy: policy.Policy{AllowedRecordTypes: []string{"A", ""}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.policy.Validate()
+			if tc.isValid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, policy.ErrInvalidPolicy)
+			}
+		})
+	}
+}
+
+func TestPolicyCheckRecordType(t *testing.T) {
+	t.Parallel()
+
+	p := policy.Policy{AllowedRecordTypes: []string{"A", "AAAA", "CNAME"}}
+
+	require.NoError(t, p.CheckRecordType("A"))
+	require.NoError(t, p.CheckRecordType("cname"))
+	require.ErrorIs(t, p.CheckRecordType("TXT"), policy.ErrViolation)
+	require.NoError(t, policy.Policy{}.CheckRecordType("TXT"))
+}
+
+func TestPolicyCheckRecordName(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name       string
+		policy     policy.Policy
+		recordName string
+		isValid    bool
+	}{
+		{name: "no rules", recordName: "*", isValid: true},
+		{name: "wildcard", policy: policy.Policy{DenyWildcardRecords: true}, recordName: "*"},
+		{name: "wildcard subdomain", policy: policy.Policy{DenyWildcardRecords: true}, recordName: "*.dev"},
+		{name: "asterisk in label", policy: policy.Policy{DenyWildcardRecords: true}, recordName: "a*b", isValid: true},
+		{name: "matching prefix", policy: policy.Policy{AllowedNamePatterns: []string{"team-a-*"}}, recordName: "team-a-web", isValid: true},
+		{name: "matching upper case", policy: policy.Policy{AllowedNamePatterns: []string{"Team-A-*"}}, recordName: "team-a-WEB", isValid: true},
+		{name: "matching subdomain", policy: policy.Policy{AllowedNamePatterns: []string{"*.team-a"}}, recordName: "www.team-a", isValid: true},
+		{name: "other prefix", policy: policy.Policy{AllowedNamePatterns: []string{"team-a-*"}}, recordName: "team-b-web"},
+		{name: "apex", policy: policy.Policy{AllowedNamePatterns: []string{"team-a-*"}}, recordName: "@"},
+		{name: "allowed apex", policy: policy.Policy{AllowedNamePatterns: []string{"team-a-*", "@"}}, recordName: "@", isValid: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.policy.CheckRecordName(tc.recordName)
+			if tc.isValid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, policy.ErrViolation)
+			}
+		})
+	}
+}
+
+func TestPolicyCheckRecordValue(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name       string
+		policy     policy.Policy
+		recordType string
+		value      string
+		wantErr    error
+	}{
+		{name: "public address", policy: policy.Policy{DenyPrivateAddresses: true}, recordType: "A", value: "192.0.2.1"},
+		{name: "RFC1918 10/8", policy: policy.Policy{DenyPrivateAddresses: true}, recordType: "A", value: "10.1.2.3", wantErr: policy.ErrViolation},
+		{name: "RFC1918 172.16/12", policy: policy.Policy{DenyPrivateAddresses: true}, recordType: "A", value: "172.31.0.1", wantErr: policy.ErrViolation},
+		{name: "RFC1918 192.168/16", policy: policy.Policy{DenyPrivateAddresses: true}, recordType: "A", value: "192.168.1.1", wantErr: policy.ErrViolation},
+		{name: "unique local IPv6", policy: policy.Policy{DenyPrivateAddresses: true}, recordType: "AAAA", value: "fd00::1", wantErr: policy.ErrViolation},
+		{name: "private addresses allowed", recordType: "A", value: "10.1.2.3"},
+		{name: "other record type", policy: policy.Policy{DenyPrivateAddresses: true}, recordType: "TXT", value: "10.1.2.3"},
+		{name: "invalid address", policy: policy.Policy{ValidateIPAddresses: true}, recordType: "A", value: "10.1.2", wantErr: utils.ErrInvalidIPAddress},
+		{name: "invalid address not validated", recordType: "A", value: "10.1.2"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.policy.CheckRecordValue(tc.recordType, tc.value)
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestPolicyCheckTTL(t *testing.T) {
+	t.Parallel()
+
+	p := policy.Policy{MinTTL: 60, MaxTTL: 3600}
+
+	require.NoError(t, p.CheckTTL(60))
+	require.NoError(t, p.CheckTTL(3600))
+	require.ErrorIs(t, p.CheckTTL(30), policy.ErrViolation)
+	require.ErrorIs(t, p.CheckTTL(86400), policy.ErrViolation)
+	assert.NoError(t, policy.Policy{}.CheckTTL(86400))
+}
+
+func TestPolicyCheckProtectedRecord(t *testing.T) {
+	t.Parallel()
+
+	p := policy.Policy{ProtectedRecords: []policy.ProtectedRecord{
+		{Name: "@", Type: "MX"},
+		{Zone: "example.*", Name: "_dmarc"},
+	}}
+
+	require.NoError(t, p.Validate())
+
+	for _, tc := range []struct {
+		name       string
+		zone       string
+		recordName string
+		recordType string
+		protected  bool
+	}{
+		{name: "apex MX", zone: "example.com", recordName: "@", recordType: "MX", protected: true},
+		{name: "lower case type", zone: "example.org", recordName: "@", recordType: "mx", protected: true},
+		{name: "apex A", zone: "example.com", recordName: "@", recordType: "A"},
+		{name: "MX of a sub domain", zone: "example.com", recordName: "mail", recordType: "MX"},
+		{name: "DMARC of any type", zone: "example.com.", recordName: "_DMARC", recordType: "TXT", protected: true},
+		{name: "DMARC of another zone", zone: "example.net.de", recordName: "_dmarc", recordType: "TXT", protected: true},
+		{name: "DMARC of an unmatched zone", zone: "other.com", recordName: "_dmarc", recordType: "TXT"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := p.CheckProtectedRecord(tc.zone, tc.recordName, tc.recordType)
+			if tc.protected {
+				require.ErrorIs(t, err, policy.ErrProtected)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	require.ErrorIs(t, policy.Policy{ProtectedRecords: []policy.ProtectedRecord{{Name: "[a"}}}.Validate(), policy.ErrInvalidPolicy)
+}
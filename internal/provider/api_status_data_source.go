@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &apiStatusDataSource{}
+
+func NewAPIStatusDataSource() datasource.DataSource {
+	return &apiStatusDataSource{}
+}
+
+// apiStatusDataSource defines the data source implementation.
+type apiStatusDataSource struct {
+	provider *providerClient
+}
+
+// apiStatusDataSourceModel describes the data source data model.
+type apiStatusDataSourceModel struct {
+	RateLimitLimit     types.Int64 `tfsdk:"ratelimit_limit"`
+	RateLimitRemaining types.Int64 `tfsdk:"ratelimit_remaining"`
+	RateLimitReset     types.Int64 `tfsdk:"ratelimit_reset"`
+	ZoneCount          types.Int64 `tfsdk:"zone_count"`
+	RequestCount       types.Int64 `tfsdk:"request_count"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (d *apiStatusDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_status"
+}
+
+func (d *apiStatusDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Provides the current rate limit of the Hetzner DNS API and the usage of the API by the provider, " +
+			"e.g. to warn in a `check` block before an apply which could exceed the rate limit. The data source requests the " +
+			"number of zones, and the rate limit is taken from the response to this request.",
+
+		Attributes: map[string]schema.Attribute{
+			"ratelimit_limit": schema.Int64Attribute{
+				MarkdownDescription: "The number of requests allowed per rate limit window, from the `ratelimit-limit` header. " +
+					"Null if the API didn't send the header",
+				Computed: true,
+			},
+			"ratelimit_remaining": schema.Int64Attribute{
+				MarkdownDescription: "The number of requests remaining in the current rate limit window, from the `ratelimit-remaining` header. " +
+					"Null if the API didn't send the header",
+				Computed: true,
+			},
+			"ratelimit_reset": schema.Int64Attribute{
+				MarkdownDescription: "The seconds until the rate limit window resets, from the `ratelimit-reset` header. " +
+					"Null if the API didn't send the header",
+				Computed: true,
+			},
+			"zone_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of zones the API token has access to",
+				Computed:            true,
+			},
+			"request_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of API requests the provider made so far in this Terraform run, including the ones of this data source",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockWithOpts(ctx, timeouts.Opts{
+				ReadDescription: `[Operation Timeouts](https://developer.hashicorp.com/terraform/language/resources/syntax#operation-timeouts) consisting of
+numbers and unit suffixes, such as "30s" or "2h45m".
+Valid time units are "s" (seconds), "m" (minutes), "h" (hours). Default: 5m`,
+			}),
+		},
+	}
+}
+
+func (d *apiStatusDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*providerClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.provider = provider
+}
+
+func (d *apiStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data apiStatusDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var (
+		err       error
+		zoneCount int64
+	)
+
+	err = d.provider.retry(ctx, readTimeout, func(ctx context.Context) error {
+		zoneCount, err = d.provider.apiClient.CountZones(ctx)
+
+		return err
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to count zones, got error: %s", err))
+
+		return
+	}
+
+	status := d.provider.apiClient.Status()
+
+	data.RateLimitLimit = types.Int64PointerValue(status.RateLimitLimit)
+	data.RateLimitRemaining = types.Int64PointerValue(status.RateLimitRemaining)
+	data.RateLimitReset = types.Int64PointerValue(status.RateLimitReset)
+	data.ZoneCount = types.Int64Value(zoneCount)
+	data.RequestCount = types.Int64Value(status.Requests)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
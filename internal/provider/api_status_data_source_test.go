@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api"
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api/fake"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccAPIStatusDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "hetznerdns_api_status" "status" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.hetznerdns_api_status.status", "ratelimit_limit"),
+					resource.TestCheckResourceAttrSet("data.hetznerdns_api_status.status", "ratelimit_remaining"),
+					resource.TestCheckResourceAttrSet("data.hetznerdns_api_status.status", "zone_count"),
+					resource.TestCheckResourceAttrSet("data.hetznerdns_api_status.status", "request_count"),
+				),
+			},
+		},
+	})
+}
+
+func TestAPIStatusDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	provider, _, _ := newFaultTestProvider(t, 1)
+
+	_, err := provider.apiClient.CreateZone(ctx, api.CreateZoneOpts{Name: "example.org", TTL: 3600})
+	require.NoError(t, err)
+
+	d := &apiStatusDataSource{provider: provider}
+
+	var schemaResp datasource.SchemaResponse
+
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), schemaResp.Diagnostics)
+
+	objectType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	require.True(t, ok)
+
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, typ := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(typ, nil)
+	}
+
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+
+	d.Read(ctx, datasource.ReadRequest{Config: config}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var data apiStatusDataSourceModel
+
+	require.False(t, resp.State.Get(ctx, &data).HasError())
+
+	// Both zones were created with the same API client as the data source.
+	assert.Equal(t, int64(2), data.ZoneCount.ValueInt64())
+	assert.Equal(t, int64(fake.DefaultRateLimit), data.RateLimitLimit.ValueInt64())
+	assert.Equal(t, int64(3), data.RequestCount.ValueInt64())
+	assert.False(t, data.RateLimitRemaining.IsNull())
+	assert.False(t, data.RateLimitReset.IsNull())
+}
@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// apiTokenCommandTimeout is the time api_token_command may take to print the API token.
+const apiTokenCommandTimeout = 30 * time.Second
+
+// configureAPIToken returns the API token from the first configured source. The attributes in the provider
+// configuration take precedence over the env variables, in the order api_token, api_token_file and
+// api_token_command, followed by HETZNER_DNS_TOKEN, HETZNER_DNS_API_TOKEN, HETZNER_DNS_TOKEN_FILE and
+// HETZNER_DNS_TOKEN_COMMAND. The token is never part of the returned diagnostics.
+func configureAPIToken(ctx context.Context, data hetznerDNSProviderModel) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	switch {
+	case data.ApiToken.ValueString() != "":
+		return data.ApiToken.ValueString(), diags
+	case data.APITokenFile.ValueString() != "":
+		return readAPITokenFile(data.APITokenFile.ValueString(), path.Root("api_token_file"))
+	case len(data.APITokenCommand.Elements()) > 0:
+		var command []string
+
+		diags.Append(data.APITokenCommand.ElementsAs(ctx, &command, false)...)
+
+		if diags.HasError() {
+			return "", diags
+		}
+
+		return runAPITokenCommand(ctx, command, path.Root("api_token_command"))
+	}
+
+	if apiToken := os.Getenv("HETZNER_DNS_TOKEN"); apiToken != "" {
+		return apiToken, diags
+	}
+
+	// Still support the deprecated env var for now but show a warning if it's used.
+	if apiToken := os.Getenv("HETZNER_DNS_API_TOKEN"); apiToken != "" {
+		diags.AddWarning("Deprecated API Token Environment Variable",
+			"The environment variable `HETZNER_DNS_API_TOKEN` is deprecated and will be removed in a future release. "+
+				"Please use `HETZNER_DNS_TOKEN` instead.",
+		)
+
+		return apiToken, diags
+	}
+
+	if filename := os.Getenv("HETZNER_DNS_TOKEN_FILE"); filename != "" {
+		return readAPITokenFile(filename, path.Root("api_token_file"))
+	}
+
+	if command := strings.Fields(os.Getenv("HETZNER_DNS_TOKEN_COMMAND")); len(command) > 0 {
+		return runAPITokenCommand(ctx, command, path.Root("api_token_command"))
+	}
+
+	diags.AddAttributeError(path.Root("api_token"), "Missing API Token Configuration",
+		"While configuring the client, the API token was not found in the provider configuration attributes api_token, "+
+			"api_token_file or api_token_command or in the environment variables HETZNER_DNS_TOKEN, HETZNER_DNS_TOKEN_FILE "+
+			"or HETZNER_DNS_TOKEN_COMMAND.",
+	)
+
+	return "", diags
+}
+
+// readAPITokenFile reads the API token from a file. Leading and trailing whitespace is removed.
+func readAPITokenFile(filename string, attributePath path.Path) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		diags.AddAttributeError(attributePath, "Invalid API Token File", fmt.Sprintf("reading the API token: %s", err))
+
+		return "", diags
+	}
+
+	apiToken := strings.TrimSpace(string(data))
+	if apiToken == "" {
+		diags.AddAttributeError(attributePath, "Invalid API Token File", fmt.Sprintf("the file %s is empty", filename))
+	}
+
+	return apiToken, diags
+}
+
+// runAPITokenCommand runs the command, given as the program and its arguments, and returns its output as
+// the API token. Leading and trailing whitespace is removed. The output is never logged.
+func runAPITokenCommand(ctx context.Context, command []string, attributePath path.Path) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if len(command) == 0 || command[0] == "" {
+		diags.AddAttributeError(attributePath, "Invalid API Token Command", "the command must not be empty")
+
+		return "", diags
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, apiTokenCommandTimeout)
+	defer cancel()
+
+	var stdout bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...) //nolint:gosec // The command is configured by the user on purpose.
+	// The output of the command isn't added to diagnostics, as it may contain the token or other secrets.
+	cmd.Stdout = &stdout
+
+	tflog.Debug(ctx, fmt.Sprintf("running %s to get the API token", command[0]))
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("timeout after %s", apiTokenCommandTimeout)
+		}
+
+		diags.AddAttributeError(attributePath, "Invalid API Token Command",
+			fmt.Sprintf("running %s: %s. Run the command in a shell to see its error output.", command[0], err))
+
+		return "", diags
+	}
+
+	apiToken := strings.TrimSpace(stdout.String())
+	if apiToken == "" {
+		diags.AddAttributeError(attributePath, "Invalid API Token Command", fmt.Sprintf("%s didn't print an API token", command[0]))
+	}
+
+	return apiToken, diags
+}
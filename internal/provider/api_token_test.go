@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAPIToken = "secret-api-token"
+
+// stringList returns a list of the given strings.
+func stringList(args ...string) types.List {
+	values := make([]attr.Value, 0, len(args))
+	for _, arg := range args {
+		values = append(values, types.StringValue(arg))
+	}
+
+	return types.ListValueMust(types.StringType, values)
+}
+
+//nolint:paralleltest // t.Setenv can't be used in parallel tests
+func TestConfigureAPIToken(t *testing.T) {
+	dir := t.TempDir()
+
+	tokenFile := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte(testAPIToken+"\n"), 0o600))
+
+	emptyFile := filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(emptyFile, []byte(" \n"), 0o600))
+
+	for _, tc := range []struct {
+		name        string
+		data        hetznerDNSProviderModel
+		env         map[string]string
+		wantErr     string
+		wantWarning string
+	}{
+		{
+			name: "api_token",
+			data: hetznerDNSProviderModel{ApiToken: types.StringValue(testAPIToken)},
+			env:  map[string]string{"HETZNER_DNS_TOKEN": "other"},
+		},
+		{
+			name: "api_token_file",
+			data: hetznerDNSProviderModel{APITokenFile: types.StringValue(tokenFile)},
+			env:  map[string]string{"HETZNER_DNS_TOKEN": "other"},
+		},
+		{
+			name: "api_token_command",
+			data: hetznerDNSProviderModel{APITokenCommand: stringList("echo", " "+testAPIToken+" ")},
+			env:  map[string]string{"HETZNER_DNS_TOKEN": "other"},
+		},
+		{
+			name: "token env variable takes precedence over other env variables",
+			env: map[string]string{
+				"HETZNER_DNS_TOKEN":         testAPIToken,
+				"HETZNER_DNS_API_TOKEN":     "other",
+				"HETZNER_DNS_TOKEN_FILE":    emptyFile,
+				"HETZNER_DNS_TOKEN_COMMAND": "false",
+			},
+		},
+		{
+			name:        "deprecated env variable",
+			env:         map[string]string{"HETZNER_DNS_API_TOKEN": testAPIToken},
+			wantWarning: "Deprecated API Token Environment Variable",
+		},
+		{
+			name: "token file env variable",
+			env: map[string]string{
+				"HETZNER_DNS_TOKEN_FILE":    tokenFile,
+				"HETZNER_DNS_TOKEN_COMMAND": "false",
+			},
+		},
+		{
+			name: "token command env variable",
+			env:  map[string]string{"HETZNER_DNS_TOKEN_COMMAND": "echo " + testAPIToken},
+		},
+		{
+			name:    "missing",
+			wantErr: "Missing API Token Configuration",
+		},
+		{
+			name:    "missing file",
+			data:    hetznerDNSProviderModel{APITokenFile: types.StringValue(filepath.Join(dir, "missing"))},
+			wantErr: "Invalid API Token File",
+		},
+		{
+			name:    "empty file",
+			data:    hetznerDNSProviderModel{APITokenFile: types.StringValue(emptyFile)},
+			wantErr: "Invalid API Token File",
+		},
+		{
+			name:    "failing command",
+			data:    hetznerDNSProviderModel{APITokenCommand: stringList("sh", "-c", "echo "+testAPIToken+"; exit 1")},
+			wantErr: "Invalid API Token Command",
+		},
+		{
+			name:    "failing command with error output",
+			data:    hetznerDNSProviderModel{APITokenCommand: stringList("sh", "-c", "echo invalid token "+testAPIToken+" >&2; exit 1")},
+			wantErr: "Invalid API Token Command",
+		},
+		{
+			name:    "command without output",
+			data:    hetznerDNSProviderModel{APITokenCommand: stringList("true")},
+			wantErr: "Invalid API Token Command",
+		},
+		{
+			name:    "unknown command",
+			data:    hetznerDNSProviderModel{APITokenCommand: stringList(filepath.Join(dir, "missing"))},
+			wantErr: "Invalid API Token Command",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, name := range []string{
+				"HETZNER_DNS_TOKEN", "HETZNER_DNS_API_TOKEN", "HETZNER_DNS_TOKEN_FILE", "HETZNER_DNS_TOKEN_COMMAND",
+			} {
+				t.Setenv(name, tc.env[name])
+
+				if _, ok := tc.env[name]; !ok {
+					require.NoError(t, os.Unsetenv(name))
+				}
+			}
+
+			got, diags := configureAPIToken(context.Background(), tc.data)
+
+			for _, d := range diags {
+				assert.NotContains(t, d.Detail(), testAPIToken)
+			}
+
+			if tc.wantErr != "" {
+				require.True(t, diags.HasError())
+				assert.Equal(t, tc.wantErr, diags.Errors()[0].Summary())
+
+				return
+			}
+
+			require.False(t, diags.HasError(), diags)
+			assert.Equal(t, testAPIToken, got)
+
+			if tc.wantWarning != "" {
+				require.Len(t, diags.Warnings(), 1)
+				assert.Equal(t, tc.wantWarning, diags.Warnings()[0].Summary())
+			}
+		})
+	}
+}
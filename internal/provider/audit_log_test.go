@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordResourceAuditLog(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	provider, _, zone := newFaultTestProvider(t, 1)
+	provider.txtFormatter = true
+
+	logPath := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	auditLog, err := api.NewAuditLog(logPath)
+	require.NoError(t, err)
+
+	provider.apiClient.SetAuditLog(auditLog)
+
+	r := &recordResource{provider: provider}
+	schema := testResourceSchema(t, r).Schema
+
+	plan := tfsdk.Plan{Schema: schema}
+	require.False(t, plan.Set(ctx, &recordResourceModel{
+		ID:       types.StringUnknown(),
+		ZoneID:   types.StringValue(zone.ID),
+		Name:     newRecordNameValue("www"),
+		FQDN:     types.StringUnknown(),
+		Type:     types.StringValue("TXT"),
+		Value:    types.StringValue("hello world"),
+		TTL:      types.Int64Null(),
+		Timeouts: nullTimeouts(),
+	}).HasError())
+
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+
+	var state recordResourceModel
+
+	require.False(t, createResp.State.Get(ctx, &state).HasError())
+
+	deleteResp := resource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+
+	data, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+
+	var created, deleted api.AuditEntry
+
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &created))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &deleted))
+
+	assert.Equal(t, "POST", created.Method)
+	assert.Equal(t, "hetznerdns_record", created.ResourceType)
+	assert.Equal(t, state.ID.ValueString(), created.ResourceID)
+	assert.Equal(t, zone.ID, created.ZoneID)
+	assert.Equal(t, "www", created.RecordName)
+	assert.Equal(t, `"hello world"`, created.NewValue)
+
+	assert.Equal(t, "DELETE", deleted.Method)
+	assert.Equal(t, "hetznerdns_record", deleted.ResourceType)
+	assert.Equal(t, state.ID.ValueString(), deleted.ResourceID)
+	assert.Equal(t, zone.ID, deleted.ZoneID)
+	assert.Equal(t, "example.com", deleted.Zone)
+	assert.Equal(t, "TXT", deleted.RecordType)
+	assert.Equal(t, `"hello world"`, deleted.OldValue)
+}
@@ -0,0 +1,346 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// deleteBudget limits the number of resources deleted by the provider process, i.e. by a single plan or apply.
+// Zero limits are not set.
+type deleteBudget struct {
+	maxDeletes        int64
+	maxDeletesPerZone int64
+
+	mu        sync.Mutex
+	planned   deleteCounter
+	deleted   deleteCounter
+	positions map[string]plannedDelete
+}
+
+// plannedDelete is the position of a planned delete of a resource in the total planned deletes and in those of its zone.
+type plannedDelete struct {
+	total int64
+	zone  int64
+}
+
+// deleteCounter counts deletes in total and per zone ID.
+type deleteCounter struct {
+	total int64
+	zones map[string]int64
+}
+
+func configureDeleteBudget(data hetznerDNSProviderModel) (*deleteBudget, diag.Diagnostics) {
+	var (
+		budget deleteBudget
+		diags  diag.Diagnostics
+		err    error
+	)
+
+	for _, setting := range []struct {
+		name   string
+		attr   types.Int64
+		envVar string
+		value  *int64
+	}{
+		{name: "max_deletes_per_run", attr: data.MaxDeletesPerRun, envVar: "HETZNER_DNS_MAX_DELETES_PER_RUN", value: &budget.maxDeletes},
+		{name: "max_deletes_per_zone", attr: data.MaxDeletesPerZone, envVar: "HETZNER_DNS_MAX_DELETES_PER_ZONE", value: &budget.maxDeletesPerZone},
+	} {
+		*setting.value, err = utils.ConfigureInt64Attribute(setting.attr, setting.envVar, 0)
+		if err != nil {
+			diags.AddAttributeError(path.Root(setting.name), "must be an integer", err.Error())
+		} else if *setting.value < 0 {
+			diags.AddAttributeError(path.Root(setting.name), "must not be negative",
+				fmt.Sprintf("The number of deletes must not be negative, got: %d", *setting.value))
+		}
+	}
+
+	return &budget, diags
+}
+
+// add counts a delete of a resource of the zone and returns the new totals of all deletes and of the deletes of the zone.
+// The delete is only counted if it doesn't exceed the limits, unless force is set.
+func (c *deleteCounter) add(zoneID string, maxDeletes, maxDeletesPerZone int64, force bool) (int64, int64, bool) {
+	if c.zones == nil {
+		c.zones = make(map[string]int64)
+	}
+
+	total, zone := c.total+1, c.zones[zoneID]+1
+	exceeded := (maxDeletes > 0 && total > maxDeletes) || (maxDeletesPerZone > 0 && zone > maxDeletesPerZone)
+
+	if !exceeded || force {
+		c.total, c.zones[zoneID] = total, zone
+	}
+
+	return total, zone, exceeded
+}
+
+// reserve counts a delete of a resource of the zone before it is deleted, and returns an error if it exceeds the budget.
+func (b *deleteBudget) reserve(typeName, id, zoneID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if b == nil {
+		return diags
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	total, zone, exceeded := b.deleted.add(zoneID, b.maxDeletes, b.maxDeletesPerZone, false)
+	if !exceeded {
+		return diags
+	}
+
+	if b.maxDeletes > 0 && total > b.maxDeletes {
+		diags.AddError("Delete Budget Exceeded",
+			fmt.Sprintf("The %s %s wasn't deleted, because it would be delete number %d of this run and `max_deletes_per_run` is %d. "+
+				"Check the plan for unintended deletes and raise the limit if they are intended.", typeName, id, total, b.maxDeletes),
+		)
+
+		return diags
+	}
+
+	diags.AddError("Delete Budget Exceeded",
+		fmt.Sprintf("The %s %s wasn't deleted, because it would be delete number %d of zone %s in this run and `max_deletes_per_zone` is %d. "+
+			"Check the plan for unintended deletes and raise the limit if they are intended.", typeName, id, zone, zoneID, b.maxDeletesPerZone),
+	)
+
+	return diags
+}
+
+// plan counts a planned delete of a resource of the zone and returns a warning once the planned deletes exceed the budget.
+// A resource is counted only once, because Terraform plans it again during apply. The warning of a resource which is
+// planned again is returned again.
+func (b *deleteBudget) plan(typeName, id, zoneID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if b == nil {
+		return diags
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key := typeName + "." + id
+
+	position, ok := b.positions[key]
+	if !ok {
+		position.total, position.zone, _ = b.planned.add(zoneID, b.maxDeletes, b.maxDeletesPerZone, true)
+
+		if b.positions == nil {
+			b.positions = make(map[string]plannedDelete)
+		}
+
+		b.positions[key] = position
+	}
+
+	// Warn only once per exceeded limit.
+	if b.maxDeletes > 0 && position.total == b.maxDeletes+1 {
+		diags.AddWarning("Planned Deletes Exceed Budget",
+			fmt.Sprintf("The plan deletes more than %d resources, which is the `max_deletes_per_run` of the provider. "+
+				"The deletes beyond the budget will fail during apply. Check the plan for unintended deletes.", b.maxDeletes),
+		)
+	}
+
+	if b.maxDeletesPerZone > 0 && position.zone == b.maxDeletesPerZone+1 {
+		diags.AddWarning("Planned Deletes Exceed Budget",
+			fmt.Sprintf("The plan deletes more than %d resources of zone %s, which is the `max_deletes_per_zone` of the provider. "+
+				"The deletes beyond the budget will fail during apply. Check the plan for unintended deletes.", b.maxDeletesPerZone, zoneID),
+		)
+	}
+
+	return diags
+}
+
+// planDelete counts the planned delete of a resource against the delete budget of the provider.
+// The zone ID of the resource is read from the attribute of the prior state.
+func (p *providerClient) planDelete(ctx context.Context, req resource.ModifyPlanRequest, typeName string, zoneIDPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if p == nil || !req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return diags
+	}
+
+	diags.Append(p.planStateDelete(ctx, req.State, typeName, zoneIDPath)...)
+
+	return diags
+}
+
+// planReplace counts the planned replacement of a resource against the delete budget of the provider, like planDelete.
+// The resource is replaced if its ModifyPlan or the plan modifiers of its attributes require it. The framework runs the
+// plan modifiers of the attributes before ModifyPlan without passing their result on, so they are run again here and
+// the attributes requiring the replacement are added to the response.
+func (p *providerClient) planReplace(
+	ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, typeName string, zoneIDPath path.Path,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if p == nil || req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return diags
+	}
+
+	paths, diags := requiresReplace(ctx, req, resp.Plan)
+	if diags.HasError() {
+		return diags
+	}
+
+	resp.RequiresReplace = resp.RequiresReplace.Append(paths...)
+
+	if len(resp.RequiresReplace) == 0 {
+		return diags
+	}
+
+	diags.Append(p.planStateDelete(ctx, req.State, typeName, zoneIDPath)...)
+
+	return diags
+}
+
+// planStateDelete counts the planned delete of the resource in the state against the delete budget.
+func (p *providerClient) planStateDelete(ctx context.Context, state tfsdk.State, typeName string, zoneIDPath path.Path) diag.Diagnostics {
+	var (
+		diags      diag.Diagnostics
+		id, zoneID types.String
+	)
+
+	diags.Append(state.GetAttribute(ctx, path.Root("id"), &id)...)
+	diags.Append(state.GetAttribute(ctx, zoneIDPath, &zoneID)...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(p.deletes.plan(typeName, id.ValueString(), zoneID.ValueString())...)
+
+	return diags
+}
+
+// requiresReplace runs the plan modifiers of the top level attributes of the schema on the plan and returns the paths of
+// the attributes whose plan modifiers require the replacement of the resource, sorted by name.
+func requiresReplace(ctx context.Context, req resource.ModifyPlanRequest, plan tfsdk.Plan) (path.Paths, diag.Diagnostics) {
+	var (
+		diags diag.Diagnostics
+		paths path.Paths
+	)
+
+	attributes := req.Plan.Schema.GetAttributes()
+	names := make([]string, 0, len(attributes))
+
+	for name := range attributes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		var (
+			attributePath = path.Root(name)
+			replace       bool
+			attrDiags     diag.Diagnostics
+		)
+
+		switch attribute := attributes[name].(type) {
+		case schema.StringAttribute:
+			replace, attrDiags = runPlanModifiers(ctx, req, plan, attributePath, attribute.PlanModifiers,
+				func(modifier planmodifier.String, request planModifierRequest[types.String]) (bool, diag.Diagnostics) {
+					resp := planmodifier.StringResponse{PlanValue: request.plan}
+					modifier.PlanModifyString(ctx, planmodifier.StringRequest{
+						Path: attributePath, PathExpression: attributePath.Expression(), Private: req.Private,
+						Config: req.Config, ConfigValue: request.config, Plan: plan, PlanValue: request.plan, State: req.State, StateValue: request.state,
+					}, &resp)
+
+					return resp.RequiresReplace, resp.Diagnostics
+				})
+		case schema.Int64Attribute:
+			replace, attrDiags = runPlanModifiers(ctx, req, plan, attributePath, attribute.PlanModifiers,
+				func(modifier planmodifier.Int64, request planModifierRequest[types.Int64]) (bool, diag.Diagnostics) {
+					resp := planmodifier.Int64Response{PlanValue: request.plan}
+					modifier.PlanModifyInt64(ctx, planmodifier.Int64Request{
+						Path: attributePath, PathExpression: attributePath.Expression(), Private: req.Private,
+						Config: req.Config, ConfigValue: request.config, Plan: plan, PlanValue: request.plan, State: req.State, StateValue: request.state,
+					}, &resp)
+
+					return resp.RequiresReplace, resp.Diagnostics
+				})
+		case schema.BoolAttribute:
+			replace, attrDiags = runPlanModifiers(ctx, req, plan, attributePath, attribute.PlanModifiers,
+				func(modifier planmodifier.Bool, request planModifierRequest[types.Bool]) (bool, diag.Diagnostics) {
+					resp := planmodifier.BoolResponse{PlanValue: request.plan}
+					modifier.PlanModifyBool(ctx, planmodifier.BoolRequest{
+						Path: attributePath, PathExpression: attributePath.Expression(), Private: req.Private,
+						Config: req.Config, ConfigValue: request.config, Plan: plan, PlanValue: request.plan, State: req.State, StateValue: request.state,
+					}, &resp)
+
+					return resp.RequiresReplace, resp.Diagnostics
+				})
+		case schema.ListAttribute:
+			replace, attrDiags = runPlanModifiers(ctx, req, plan, attributePath, attribute.PlanModifiers,
+				func(modifier planmodifier.List, request planModifierRequest[types.List]) (bool, diag.Diagnostics) {
+					resp := planmodifier.ListResponse{PlanValue: request.plan}
+					modifier.PlanModifyList(ctx, planmodifier.ListRequest{
+						Path: attributePath, PathExpression: attributePath.Expression(), Private: req.Private,
+						Config: req.Config, ConfigValue: request.config, Plan: plan, PlanValue: request.plan, State: req.State, StateValue: request.state,
+					}, &resp)
+
+					return resp.RequiresReplace, resp.Diagnostics
+				})
+		}
+
+		diags.Append(attrDiags...)
+
+		if replace {
+			paths = append(paths, attributePath)
+		}
+	}
+
+	return paths, diags
+}
+
+// planModifierRequest holds the values of an attribute a plan modifier is run with.
+type planModifierRequest[T attr.Value] struct {
+	config, plan, state T
+}
+
+// runPlanModifiers runs the plan modifiers of an attribute with its values and reports whether one of them requires
+// the replacement of the resource.
+func runPlanModifiers[T attr.Value, M any](
+	ctx context.Context, req resource.ModifyPlanRequest, plan tfsdk.Plan, attributePath path.Path, modifiers []M,
+	run func(M, planModifierRequest[T]) (bool, diag.Diagnostics),
+) (bool, diag.Diagnostics) {
+	var (
+		diags   diag.Diagnostics
+		request planModifierRequest[T]
+		replace bool
+	)
+
+	if len(modifiers) == 0 {
+		return false, diags
+	}
+
+	diags.Append(req.Config.GetAttribute(ctx, attributePath, &request.config)...)
+	diags.Append(plan.GetAttribute(ctx, attributePath, &request.plan)...)
+	diags.Append(req.State.GetAttribute(ctx, attributePath, &request.state)...)
+
+	if diags.HasError() {
+		return false, diags
+	}
+
+	for _, modifier := range modifiers {
+		modifierReplace, modifierDiags := run(modifier, request)
+		diags.Append(modifierDiags...)
+
+		replace = replace || modifierReplace
+	}
+
+	return replace, diags
+}
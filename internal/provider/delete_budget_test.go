@@ -0,0 +1,311 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // t.Setenv can't be used in parallel tests
+func TestConfigureDeleteBudget(t *testing.T) {
+	for _, tc := range []struct {
+		name              string
+		data              hetznerDNSProviderModel
+		env               map[string]string
+		maxDeletes        int64
+		maxDeletesPerZone int64
+		wantErr           string
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name:              "attributes",
+			data:              hetznerDNSProviderModel{MaxDeletesPerRun: types.Int64Value(10), MaxDeletesPerZone: types.Int64Value(5)},
+			env:               map[string]string{"HETZNER_DNS_MAX_DELETES_PER_RUN": "100"},
+			maxDeletes:        10,
+			maxDeletesPerZone: 5,
+		},
+		{
+			name:              "env variables",
+			env:               map[string]string{"HETZNER_DNS_MAX_DELETES_PER_RUN": "100", "HETZNER_DNS_MAX_DELETES_PER_ZONE": "50"},
+			maxDeletes:        100,
+			maxDeletesPerZone: 50,
+		},
+		{
+			name:    "invalid env variable",
+			env:     map[string]string{"HETZNER_DNS_MAX_DELETES_PER_RUN": "many"},
+			wantErr: "must be an integer",
+		},
+		{
+			name:    "negative",
+			env:     map[string]string{"HETZNER_DNS_MAX_DELETES_PER_ZONE": "-1"},
+			wantErr: "must not be negative",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, name := range []string{"HETZNER_DNS_MAX_DELETES_PER_RUN", "HETZNER_DNS_MAX_DELETES_PER_ZONE"} {
+				t.Setenv(name, tc.env[name])
+
+				if _, ok := tc.env[name]; !ok {
+					require.NoError(t, os.Unsetenv(name))
+				}
+			}
+
+			budget, diags := configureDeleteBudget(tc.data)
+
+			if tc.wantErr != "" {
+				require.True(t, diags.HasError())
+				assert.Equal(t, tc.wantErr, diags.Errors()[0].Summary())
+
+				return
+			}
+
+			require.False(t, diags.HasError(), diags)
+			assert.Equal(t, tc.maxDeletes, budget.maxDeletes)
+			assert.Equal(t, tc.maxDeletesPerZone, budget.maxDeletesPerZone)
+		})
+	}
+}
+
+func TestDeleteBudgetReserve(t *testing.T) {
+	t.Parallel()
+
+	budget := &deleteBudget{maxDeletes: 3, maxDeletesPerZone: 2}
+
+	require.False(t, budget.reserve("hetznerdns_record", "1", "a").HasError())
+	require.False(t, budget.reserve("hetznerdns_record", "2", "a").HasError())
+
+	diags := budget.reserve("hetznerdns_record", "3", "a")
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags.Errors()[0].Detail(), "`max_deletes_per_zone` is 2")
+
+	require.False(t, budget.reserve("hetznerdns_zone", "b", "b").HasError())
+
+	diags = budget.reserve("hetznerdns_record", "4", "c")
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags.Errors()[0].Detail(), "`max_deletes_per_run` is 3")
+
+	var unlimited *deleteBudget
+
+	assert.False(t, unlimited.reserve("hetznerdns_record", "1", "a").HasError())
+}
+
+func TestDeleteBudgetPlan(t *testing.T) {
+	t.Parallel()
+
+	budget := &deleteBudget{maxDeletes: 3, maxDeletesPerZone: 1}
+
+	assert.Empty(t, budget.plan("hetznerdns_record", "1", "a"))
+
+	diags := budget.plan("hetznerdns_record", "2", "a")
+	require.Len(t, diags.Warnings(), 1)
+	assert.Contains(t, diags.Warnings()[0].Detail(), "`max_deletes_per_zone`")
+
+	assert.Empty(t, budget.plan("hetznerdns_record", "3", "b"))
+
+	diags = budget.plan("hetznerdns_record", "4", "c")
+	require.Len(t, diags.Warnings(), 1)
+	assert.Contains(t, diags.Warnings()[0].Detail(), "`max_deletes_per_run`")
+
+	// Resources planned again are counted once and get the same warnings.
+	assert.Empty(t, budget.plan("hetznerdns_record", "1", "a"))
+	assert.Len(t, budget.plan("hetznerdns_record", "2", "a").Warnings(), 1)
+	assert.Equal(t, int64(4), budget.planned.total)
+
+	// Planned deletes are counted separately from deletes during apply.
+	assert.False(t, budget.reserve("hetznerdns_record", "1", "a").HasError())
+}
+
+func TestRecordResourceDeleteBudget(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	provider, server, zone := newFaultTestProvider(t, 1)
+	provider.deletes = &deleteBudget{maxDeletes: 1}
+	r := &recordResource{provider: provider}
+	schema := testResourceSchema(t, r).Schema
+
+	states := make([]tfsdk.State, 0, 2)
+
+	for _, name := range []string{"www", "mail"} {
+		record, err := provider.apiClient.CreateRecord(ctx, api.CreateRecordOpts{ZoneID: zone.ID, Name: name, Type: "A", Value: "192.0.2.1"})
+		require.NoError(t, err)
+
+		state := tfsdk.State{Schema: schema}
+		require.False(t, state.Set(ctx, &recordResourceModel{
+			ID:                   types.StringValue(record.ID),
+			ZoneID:               types.StringValue(zone.ID),
+			ZoneName:             types.StringNull(),
+			Name:                 newRecordNameValue(name),
+			FQDN:                 types.StringValue(name + ".example.com."),
+			Type:                 types.StringValue("A"),
+			Value:                types.StringValue("192.0.2.1"),
+			TTL:                  types.Int64Null(),
+			EffectiveTTL:         types.Int64Value(zone.TTL),
+			AllowProtectedChange: types.BoolValue(false),
+			Timeouts:             nullTimeouts(),
+		}).HasError())
+
+		states = append(states, state)
+	}
+
+	destroy := tfsdk.Plan{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+
+	var warnings int
+
+	for _, state := range states {
+		resp := resource.ModifyPlanResponse{Plan: destroy}
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{State: state, Plan: destroy}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		warnings += resp.Diagnostics.WarningsCount()
+	}
+
+	assert.Equal(t, 1, warnings)
+
+	records := len(server.Records(zone.ID))
+
+	resp := resource.DeleteResponse{State: states[0]}
+	r.Delete(ctx, resource.DeleteRequest{State: states[0]}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	resp = resource.DeleteResponse{State: states[1]}
+	r.Delete(ctx, resource.DeleteRequest{State: states[1]}, &resp)
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Delete Budget Exceeded", resp.Diagnostics.Errors()[0].Summary())
+
+	assert.Len(t, server.Records(zone.ID), records-1)
+}
+
+func TestRecordResourceDeleteBudgetPlanTwice(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	provider, _, zone := newFaultTestProvider(t, 1)
+	provider.deletes = &deleteBudget{maxDeletes: 1}
+	r := &recordResource{provider: provider}
+	schema := testResourceSchema(t, r).Schema
+
+	requests := make([]resource.ModifyPlanRequest, 0, 2)
+
+	for _, name := range []string{"www", "mail"} {
+		record, err := provider.apiClient.CreateRecord(ctx, api.CreateRecordOpts{ZoneID: zone.ID, Name: name, Type: "A", Value: "192.0.2.1"})
+		require.NoError(t, err)
+
+		model := recordResourceModel{
+			ID:                   types.StringValue(record.ID),
+			ZoneID:               types.StringValue(zone.ID),
+			ZoneName:             types.StringNull(),
+			Name:                 newRecordNameValue(name),
+			FQDN:                 types.StringValue(name + ".example.com."),
+			Type:                 types.StringValue("A"),
+			Value:                types.StringValue("192.0.2.1"),
+			TTL:                  types.Int64Null(),
+			EffectiveTTL:         types.Int64Value(zone.TTL),
+			AllowProtectedChange: types.BoolValue(false),
+			Timeouts:             nullTimeouts(),
+		}
+
+		state := tfsdk.State{Schema: schema}
+		require.False(t, state.Set(ctx, &model).HasError())
+
+		// The www record is destroyed, the mail record is replaced because of its new type.
+		plan := tfsdk.Plan{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+		config := tfsdk.Config{Schema: schema, Raw: plan.Raw}
+
+		if name == "mail" {
+			model.Type = types.StringValue("AAAA")
+			model.Value = types.StringValue("2001:db8::1")
+
+			require.False(t, plan.Set(ctx, &model).HasError())
+
+			config.Raw = plan.Raw
+		}
+
+		requests = append(requests, resource.ModifyPlanRequest{Config: config, State: state, Plan: plan})
+	}
+
+	// Terraform plans the resources again during apply.
+	for range 2 {
+		var warnings int
+
+		for _, req := range requests {
+			resp := resource.ModifyPlanResponse{Plan: req.Plan}
+			r.ModifyPlan(ctx, req, &resp)
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			if !req.Plan.Raw.IsNull() {
+				assert.Equal(t, path.Paths{path.Root("type")}, resp.RequiresReplace)
+			}
+
+			warnings += resp.Diagnostics.WarningsCount()
+		}
+
+		assert.Equal(t, 1, warnings)
+		assert.Equal(t, int64(2), provider.deletes.planned.total)
+	}
+}
+
+func TestZoneResourceDeleteBudgetReplace(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &zoneResource{provider: &providerClient{deletes: &deleteBudget{maxDeletes: 1}}}
+	schema := testResourceSchema(t, r).Schema
+
+	var warnings int
+
+	for _, tc := range []struct {
+		id          string
+		name        string
+		ttl         int64
+		wantReplace bool
+	}{
+		{id: "1", name: "example.org", ttl: 3600, wantReplace: true},
+		{id: "2", name: "example.com", ttl: 60},
+		{id: "3", name: "example.net", ttl: 3600, wantReplace: true},
+	} {
+		model := zoneResourceModel{
+			ID:                       types.StringValue(tc.id),
+			Name:                     types.StringValue("example.com"),
+			TTL:                      types.Int64Value(3600),
+			NS:                       types.ListNull(types.StringType),
+			AdoptExisting:            types.BoolValue(false),
+			DeleteProtection:         types.BoolValue(false),
+			PreventDeleteWithRecords: types.BoolValue(false),
+			AllowProtectedChange:     types.BoolValue(false),
+			Timeouts:                 nullTimeouts(),
+		}
+
+		state := tfsdk.State{Schema: schema}
+		require.False(t, state.Set(ctx, &model).HasError())
+
+		model.Name = types.StringValue(tc.name)
+		model.TTL = types.Int64Value(tc.ttl)
+
+		plan := tfsdk.Plan{Schema: schema}
+		require.False(t, plan.Set(ctx, &model).HasError())
+
+		resp := resource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: schema, Raw: plan.Raw}, State: state, Plan: plan}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		assert.Equal(t, tc.wantReplace, len(resp.RequiresReplace) > 0, resp.RequiresReplace)
+
+		warnings += resp.Diagnostics.WarningsCount()
+	}
+
+	// The second replacement exceeds the budget, the update isn't counted.
+	assert.Equal(t, 1, warnings)
+	assert.Equal(t, int64(2), r.provider.deletes.planned.total)
+}
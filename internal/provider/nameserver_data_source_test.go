@@ -26,6 +26,8 @@ func prepareKnownValues(nameserver []string) []knownvalue.Check {
 }
 
 func TestAccNameserversDataSource_Valid(t *testing.T) {
+	testAccSkipOffline(t)
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -95,6 +97,8 @@ func TestAccNameserversDataSource_InvalidType(t *testing.T) {
 }
 
 func TestAccNameserversDataSource_DefaultType(t *testing.T) {
+	testAccSkipOffline(t)
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/policy"
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// policyModel describes the policy block of the provider configuration.
+type policyModel struct {
+	AllowedRecordTypes   types.List `tfsdk:"allowed_record_types"`
+	AllowedNamePatterns  types.List `tfsdk:"allowed_name_patterns"`
+	DenyWildcardRecords  types.Bool `tfsdk:"deny_wildcard_records"`
+	DenyPrivateAddresses types.Bool `tfsdk:"deny_private_addresses"`
+	ValidateIPAddresses  types.Bool `tfsdk:"validate_ip_addresses"`
+}
+
+// protectedRecordModel describes a protected_records block of the provider configuration.
+type protectedRecordModel struct {
+	Zone types.String `tfsdk:"zone"`
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+}
+
+func configurePolicy(ctx context.Context, data hetznerDNSProviderModel, ttl ttlConfig) (policy.Policy, diag.Diagnostics) {
+	var (
+		config = policy.Policy{MinTTL: ttl.minTTL, MaxTTL: ttl.maxTTL}
+		diags  diag.Diagnostics
+		model  policyModel
+		err    error
+	)
+
+	if data.Policy != nil {
+		model = *data.Policy
+	}
+
+	config.AllowedRecordTypes = configureStringListAttribute(ctx, model.AllowedRecordTypes, "HETZNER_DNS_POLICY_ALLOWED_RECORD_TYPES", &diags)
+	config.AllowedNamePatterns = configureStringListAttribute(ctx, model.AllowedNamePatterns, "HETZNER_DNS_POLICY_ALLOWED_NAME_PATTERNS", &diags)
+
+	config.DenyWildcardRecords, err = utils.ConfigureBoolAttribute(model.DenyWildcardRecords, "HETZNER_DNS_POLICY_DENY_WILDCARD_RECORDS", false)
+	if err != nil {
+		diags.AddAttributeError(path.Root("policy").AtName("deny_wildcard_records"), "must be a boolean", err.Error())
+	}
+
+	config.DenyPrivateAddresses, err = utils.ConfigureBoolAttribute(model.DenyPrivateAddresses, "HETZNER_DNS_POLICY_DENY_PRIVATE_ADDRESSES", false)
+	if err != nil {
+		diags.AddAttributeError(path.Root("policy").AtName("deny_private_addresses"), "must be a boolean", err.Error())
+	}
+
+	// The deprecated enable_ip_validation is only used if validate_ip_addresses isn't set.
+	if _, ok := os.LookupEnv("HETZNER_DNS_POLICY_VALIDATE_IP_ADDRESSES"); model.ValidateIPAddresses.IsNull() && !ok {
+		config.ValidateIPAddresses, err = utils.ConfigureBoolAttribute(data.EnableIPValidation, "HETZNER_DNS_ENABLE_IP_VALIDATION", true)
+		if err != nil {
+			diags.AddAttributeError(path.Root("enable_ip_validation"), "must be a boolean", err.Error())
+		}
+	} else {
+		config.ValidateIPAddresses, err = utils.ConfigureBoolAttribute(model.ValidateIPAddresses, "HETZNER_DNS_POLICY_VALIDATE_IP_ADDRESSES", true)
+		if err != nil {
+			diags.AddAttributeError(path.Root("policy").AtName("validate_ip_addresses"), "must be a boolean", err.Error())
+		}
+	}
+
+	for i, record := range data.ProtectedRecords {
+		protected := policy.ProtectedRecord{
+			Zone: record.Zone.ValueString(),
+			Name: record.Name.ValueString(),
+			Type: record.Type.ValueString(),
+		}
+
+		if err := protected.Validate(); err != nil {
+			diags.AddAttributeError(path.Root("protected_records").AtListIndex(i), "Invalid Policy", err.Error())
+		}
+
+		config.ProtectedRecords = append(config.ProtectedRecords, protected)
+	}
+
+	if diags.HasError() {
+		return config, diags
+	}
+
+	if err := config.Validate(); err != nil {
+		diags.AddAttributeError(path.Root("policy"), "Invalid Policy", err.Error())
+	}
+
+	return config, diags
+}
+
+// configureStringListAttribute returns the elements of the list, or the comma separated values of the env variable if the list is null.
+func configureStringListAttribute(ctx context.Context, attr types.List, envVar string, diags *diag.Diagnostics) []string {
+	var values []string
+
+	if !attr.IsNull() {
+		diags.Append(attr.ElementsAs(ctx, &values, false)...)
+
+		return values
+	}
+
+	for _, value := range strings.Split(os.Getenv(envVar), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// policyViolation returns an attribute error if err is a violation of the policy.
+func policyViolation(attributePath path.Path, err error) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if err != nil {
+		diags.AddAttributeError(attributePath, "Policy Violation", err.Error())
+	}
+
+	return diags
+}
+
+// checkTTLPolicy checks a planned TTL against the policy of the provider. Null and unknown TTLs are not checked.
+func (p *providerClient) checkTTLPolicy(attributePath path.Path, ttl types.Int64) diag.Diagnostics {
+	if ttl.IsNull() || ttl.IsUnknown() {
+		return nil
+	}
+
+	return policyViolation(attributePath, p.policy.CheckTTL(ttl.ValueInt64()))
+}
+
+// checkProtectedRecord returns an error if the record matches a protected record of the provider.
+// The name of the record is relative to its zone.
+func (p *providerClient) checkProtectedRecord(zone, name, recordType, detail string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if err := p.policy.CheckProtectedRecord(zone, name, recordType); err != nil {
+		diags.AddError("Protected Record", err.Error()+". "+detail)
+	}
+
+	return diags
+}
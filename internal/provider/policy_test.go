@@ -0,0 +1,499 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api"
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api/fake"
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/policy"
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // t.Setenv can't be used in parallel tests
+func TestConfigurePolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		data    hetznerDNSProviderModel
+		env     map[string]string
+		want    policy.Policy
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			want: policy.Policy{ValidateIPAddresses: true},
+		},
+		{
+			name: "policy block",
+			data: hetznerDNSProviderModel{Policy: &policyModel{
+				AllowedRecordTypes:   stringList("A", "CNAME"),
+				AllowedNamePatterns:  stringList("team-a-*"),
+				DenyWildcardRecords:  types.BoolValue(true),
+				DenyPrivateAddresses: types.BoolValue(true),
+				ValidateIPAddresses:  types.BoolValue(false),
+			}},
+			want: policy.Policy{
+				AllowedRecordTypes:   []string{"A", "CNAME"},
+				AllowedNamePatterns:  []string{"team-a-*"},
+				DenyWildcardRecords:  true,
+				DenyPrivateAddresses: true,
+			},
+		},
+		{
+			name: "env variables",
+			env: map[string]string{
+				"HETZNER_DNS_POLICY_ALLOWED_RECORD_TYPES":   "A, AAAA",
+				"HETZNER_DNS_POLICY_ALLOWED_NAME_PATTERNS":  "team-a-*,*.team-a",
+				"HETZNER_DNS_POLICY_DENY_WILDCARD_RECORDS":  "true",
+				"HETZNER_DNS_POLICY_DENY_PRIVATE_ADDRESSES": "true",
+				"HETZNER_DNS_POLICY_VALIDATE_IP_ADDRESSES":  "false",
+				"HETZNER_DNS_ENABLE_IP_VALIDATION":          "true",
+			},
+			want: policy.Policy{
+				AllowedRecordTypes:   []string{"A", "AAAA"},
+				AllowedNamePatterns:  []string{"team-a-*", "*.team-a"},
+				DenyWildcardRecords:  true,
+				DenyPrivateAddresses: true,
+			},
+		},
+		{
+			name: "deprecated enable_ip_validation",
+			data: hetznerDNSProviderModel{EnableIPValidation: types.BoolValue(false)},
+			want: policy.Policy{},
+		},
+		{
+			name: "validate_ip_addresses takes precedence over enable_ip_validation",
+			data: hetznerDNSProviderModel{
+				EnableIPValidation: types.BoolValue(false),
+				Policy:             &policyModel{ValidateIPAddresses: types.BoolValue(true)},
+			},
+			want: policy.Policy{ValidateIPAddresses: true},
+		},
+		{
+			name: "TTL bounds",
+			data: hetznerDNSProviderModel{MinTTL: types.Int64Value(60), MaxTTL: types.Int64Value(3600)},
+			want: policy.Policy{ValidateIPAddresses: true, MinTTL: 60, MaxTTL: 3600},
+		},
+		{
+			name:    "invalid name pattern",
+			data:    hetznerDNSProviderModel{Policy: &policyModel{AllowedNamePatterns: stringList("team-[a")}},
+			wantErr: "Invalid Policy",
+		},
+		{
+			name: "protected records",
+			data: hetznerDNSProviderModel{ProtectedRecords: []protectedRecordModel{
+				{Zone: types.StringNull(), Name: types.StringValue("@"), Type: types.StringValue("MX")},
+				{Zone: types.StringValue("example.com"), Name: types.StringValue("_dmarc"), Type: types.StringNull()},
+			}},
+			want: policy.Policy{ValidateIPAddresses: true, ProtectedRecords: []policy.ProtectedRecord{
+				{Name: "@", Type: "MX"},
+				{Zone: "example.com", Name: "_dmarc"},
+			}},
+		},
+		{
+			name: "invalid protected record pattern",
+			data: hetznerDNSProviderModel{ProtectedRecords: []protectedRecordModel{
+				{Zone: types.StringNull(), Name: types.StringValue("[a"), Type: types.StringNull()},
+			}},
+			wantErr: "Invalid Policy",
+		},
+		{
+			name:    "invalid env variable",
+			env:     map[string]string{"HETZNER_DNS_POLICY_DENY_WILDCARD_RECORDS": "sometimes"},
+			wantErr: "must be a boolean",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, name := range []string{
+				"HETZNER_DNS_POLICY_ALLOWED_RECORD_TYPES", "HETZNER_DNS_POLICY_ALLOWED_NAME_PATTERNS", "HETZNER_DNS_POLICY_DENY_WILDCARD_RECORDS",
+				"HETZNER_DNS_POLICY_DENY_PRIVATE_ADDRESSES", "HETZNER_DNS_POLICY_VALIDATE_IP_ADDRESSES", "HETZNER_DNS_ENABLE_IP_VALIDATION",
+				"HETZNER_DNS_MIN_TTL", "HETZNER_DNS_MAX_TTL",
+			} {
+				t.Setenv(name, tc.env[name])
+
+				if _, ok := tc.env[name]; !ok {
+					require.NoError(t, os.Unsetenv(name))
+				}
+			}
+
+			ttl, diags := configureTTL(tc.data)
+			require.False(t, diags.HasError(), diags)
+
+			got, diags := configurePolicy(context.Background(), tc.data, ttl)
+
+			if tc.wantErr != "" {
+				require.True(t, diags.HasError())
+				assert.Equal(t, tc.wantErr, diags.Errors()[0].Summary())
+
+				return
+			}
+
+			require.False(t, diags.HasError(), diags)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRecordResourcePolicy(t *testing.T) {
+	t.Parallel()
+
+	rules := policy.Policy{
+		AllowedRecordTypes:   []string{"A", "CNAME"},
+		AllowedNamePatterns:  []string{"team-a-*", "*.team-a"},
+		DenyWildcardRecords:  true,
+		DenyPrivateAddresses: true,
+		ValidateIPAddresses:  true,
+	}
+
+	for _, tc := range []struct {
+		name       string
+		recordName string
+		recordType string
+		value      string
+		wantErr    string
+	}{
+		{name: "allowed", recordName: "team-a-web", recordType: "A", value: "192.0.2.1"},
+		{name: "allowed subdomain", recordName: "www.team-a", recordType: "CNAME", value: "team-a-web"},
+		{name: "fully qualified name", recordName: "team-a-web.example.com.", recordType: "A", value: "192.0.2.1"},
+		{name: "record type", recordName: "team-a-web", recordType: "TXT", value: "hello", wantErr: "type"},
+		{name: "private address", recordName: "team-a-web", recordType: "A", value: "10.0.0.1", wantErr: "value"},
+		{name: "name", recordName: "team-b-web", recordType: "A", value: "192.0.2.1", wantErr: "name"},
+		{name: "fully qualified name outside of patterns", recordName: "team-b-web.example.com.", recordType: "A", value: "192.0.2.1", wantErr: "name"},
+		{name: "wildcard", recordName: "*.team-a", recordType: "A", value: "192.0.2.1", wantErr: "name"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			provider, _, zone := newFaultTestProvider(t, 1)
+			provider.policy = rules
+			r := &recordResource{provider: provider}
+			schema := testResourceSchema(t, r).Schema
+
+			model := recordResourceModel{
+				ID:           types.StringUnknown(),
+				ZoneID:       types.StringValue(zone.ID),
+				ZoneName:     types.StringNull(),
+				Name:         newRecordNameValue(tc.recordName),
+				FQDN:         types.StringUnknown(),
+				Type:         types.StringValue(tc.recordType),
+				Value:        types.StringValue(tc.value),
+				TTL:          types.Int64Null(),
+				EffectiveTTL: types.Int64Unknown(),
+				Timeouts:     nullTimeouts(),
+			}
+
+			plan := tfsdk.Plan{Schema: schema}
+			require.False(t, plan.Set(ctx, &model).HasError())
+
+			validateResp := resource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schema, Raw: plan.Raw}}, &validateResp)
+
+			diags := validateResp.Diagnostics
+
+			// Terraform doesn't plan resources with an invalid configuration.
+			if !diags.HasError() {
+				state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+
+				planResp := resource.ModifyPlanResponse{Plan: plan}
+				r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: schema, Raw: plan.Raw}, State: state, Plan: plan}, &planResp)
+
+				diags.Append(planResp.Diagnostics...)
+			}
+
+			if tc.wantErr == "" {
+				require.False(t, diags.HasError(), diags)
+
+				return
+			}
+
+			require.Len(t, diags.Errors(), 1, diags)
+			assert.Equal(t, "Policy Violation", diags.Errors()[0].Summary())
+
+			withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+			require.True(t, ok)
+			assert.Equal(t, path.Root(tc.wantErr), withPath.Path())
+		})
+	}
+}
+
+func TestRecordResourcePolicyUnknownZone(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name       string
+		recordName string
+		wantErr    bool
+	}{
+		{name: "allowed", recordName: "team-a-web.example.com."},
+		{name: "outside of patterns", recordName: "team-b-web.example.com.", wantErr: true},
+		{name: "wildcard", recordName: "*.team-a.example.com.", wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			provider, server, zone := newFaultTestProvider(t, 1)
+			provider.policy = policy.Policy{AllowedNamePatterns: []string{"team-a-*", "*.team-a"}, DenyWildcardRecords: true}
+			r := &recordResource{provider: provider}
+			schema := testResourceSchema(t, r).Schema
+
+			// The zone is created in the same apply, so its ID is unknown when the record is planned.
+			model := recordResourceModel{
+				ID:                   types.StringUnknown(),
+				ZoneID:               types.StringUnknown(),
+				ZoneName:             types.StringNull(),
+				Name:                 newRecordNameValue(tc.recordName),
+				FQDN:                 types.StringUnknown(),
+				Type:                 types.StringValue("A"),
+				Value:                types.StringValue("192.0.2.1"),
+				TTL:                  types.Int64Null(),
+				EffectiveTTL:         types.Int64Unknown(),
+				AllowProtectedChange: types.BoolValue(false),
+				Timeouts:             nullTimeouts(),
+			}
+
+			plan := tfsdk.Plan{Schema: schema}
+			require.False(t, plan.Set(ctx, &model).HasError())
+
+			state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+
+			planResp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: schema, Raw: plan.Raw}, State: state, Plan: plan}, &planResp)
+			require.False(t, planResp.Diagnostics.HasError(), planResp.Diagnostics)
+
+			model.ZoneID = types.StringValue(zone.ID)
+			require.False(t, plan.Set(ctx, &model).HasError())
+
+			createResp := resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+			r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+
+			created := slices.ContainsFunc(server.Records(zone.ID), func(r fake.Record) bool { return r.Type == "A" })
+
+			if !tc.wantErr {
+				require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+				assert.True(t, created)
+
+				return
+			}
+
+			require.Len(t, createResp.Diagnostics.Errors(), 1, createResp.Diagnostics)
+			assert.Equal(t, "Policy Violation", createResp.Diagnostics.Errors()[0].Summary())
+			assert.False(t, created)
+		})
+	}
+}
+
+func TestRecordResourcePolicyUnknownValue(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	provider, server, zone := newFaultTestProvider(t, 1)
+	provider.policy = policy.Policy{DenyPrivateAddresses: true}
+	r := &recordResource{provider: provider}
+	schema := testResourceSchema(t, r).Schema
+
+	// The value is the address of a resource which is created in the same apply.
+	model := recordResourceModel{
+		ID:                   types.StringUnknown(),
+		ZoneID:               types.StringValue(zone.ID),
+		ZoneName:             types.StringNull(),
+		Name:                 newRecordNameValue("www"),
+		FQDN:                 types.StringUnknown(),
+		Type:                 types.StringValue("A"),
+		Value:                types.StringUnknown(),
+		TTL:                  types.Int64Null(),
+		EffectiveTTL:         types.Int64Unknown(),
+		AllowProtectedChange: types.BoolValue(false),
+		Timeouts:             nullTimeouts(),
+	}
+
+	plan := tfsdk.Plan{Schema: schema}
+	require.False(t, plan.Set(ctx, &model).HasError())
+
+	validateResp := resource.ValidateConfigResponse{}
+	r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schema, Raw: plan.Raw}}, &validateResp)
+	require.False(t, validateResp.Diagnostics.HasError(), validateResp.Diagnostics)
+
+	state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+
+	planResp := resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: schema, Raw: plan.Raw}, State: state, Plan: plan}, &planResp)
+	require.False(t, planResp.Diagnostics.HasError(), planResp.Diagnostics)
+
+	model.Value = types.StringValue("10.0.0.1")
+	require.False(t, plan.Set(ctx, &model).HasError())
+
+	// Terraform plans the resource again during apply once the value is known.
+	planResp = resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: schema, Raw: plan.Raw}, State: state, Plan: plan}, &planResp)
+	require.Len(t, planResp.Diagnostics.Errors(), 1, planResp.Diagnostics)
+	assert.Equal(t, "Policy Violation", planResp.Diagnostics.Errors()[0].Summary())
+
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	require.Len(t, createResp.Diagnostics.Errors(), 1, createResp.Diagnostics)
+	assert.Equal(t, "Policy Violation", createResp.Diagnostics.Errors()[0].Summary())
+
+	withPath, ok := createResp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+	require.True(t, ok)
+	assert.Equal(t, path.Root("value"), withPath.Path())
+	assert.False(t, slices.ContainsFunc(server.Records(zone.ID), func(r fake.Record) bool { return r.Type == "A" }))
+}
+
+func TestRecordResourceProtectedRecords(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		recordName  string
+		recordType  string
+		allowChange bool
+		delete      bool
+		wantErr     bool
+	}{
+		{name: "update", recordName: "@", recordType: "MX", wantErr: true},
+		{name: "delete", recordName: "@", recordType: "MX", delete: true, wantErr: true},
+		{name: "update allowed", recordName: "@", recordType: "MX", allowChange: true},
+		{name: "delete allowed", recordName: "@", recordType: "MX", allowChange: true, delete: true},
+		{name: "fully qualified name", recordName: "_dmarc.example.com.", recordType: "TXT", delete: true, wantErr: true},
+		{name: "unprotected record", recordName: "www", recordType: "MX", delete: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			provider, server, zone := newFaultTestProvider(t, 1)
+			provider.policy.ProtectedRecords = []policy.ProtectedRecord{{Name: "@", Type: "MX"}, {Name: "_dmarc"}}
+			r := &recordResource{provider: provider}
+			schema := testResourceSchema(t, r).Schema
+
+			name, err := utils.NormalizeRecordName(tc.recordName, zone.Name)
+			require.NoError(t, err)
+
+			record, err := provider.apiClient.CreateRecord(ctx, api.CreateRecordOpts{ZoneID: zone.ID, Name: name, Type: tc.recordType, Value: "10 mail.example.com."})
+			require.NoError(t, err)
+
+			model := recordResourceModel{
+				ID:                   types.StringValue(record.ID),
+				ZoneID:               types.StringValue(zone.ID),
+				ZoneName:             types.StringNull(),
+				Name:                 newRecordNameValue(tc.recordName),
+				FQDN:                 types.StringValue(utils.RecordFQDN(name, zone.Name)),
+				Type:                 types.StringValue(tc.recordType),
+				Value:                types.StringValue(record.Value),
+				TTL:                  types.Int64Null(),
+				EffectiveTTL:         types.Int64Value(zone.TTL),
+				AllowProtectedChange: types.BoolValue(tc.allowChange),
+				Timeouts:             nullTimeouts(),
+			}
+
+			state := tfsdk.State{Schema: schema}
+			require.False(t, state.Set(ctx, &model).HasError())
+
+			var diags diag.Diagnostics
+
+			if tc.delete {
+				resp := resource.DeleteResponse{State: state}
+				r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+				diags = resp.Diagnostics
+			} else {
+				model.TTL = types.Int64Value(60)
+				model.EffectiveTTL = types.Int64Value(60)
+
+				plan := tfsdk.Plan{Schema: schema}
+				require.False(t, plan.Set(ctx, &model).HasError())
+
+				resp := resource.UpdateResponse{State: state}
+				r.Update(ctx, resource.UpdateRequest{State: state, Plan: plan}, &resp)
+				diags = resp.Diagnostics
+			}
+
+			records := server.Records(zone.ID)
+			unchanged := slices.ContainsFunc(records, func(r fake.Record) bool { return r.ID == record.ID && r.TTL == nil })
+
+			if tc.wantErr {
+				require.Len(t, diags.Errors(), 1, diags)
+				assert.Equal(t, "Protected Record", diags.Errors()[0].Summary())
+				assert.True(t, unchanged)
+
+				return
+			}
+
+			require.False(t, diags.HasError(), diags)
+			assert.False(t, unchanged)
+		})
+	}
+}
+
+func TestZoneResourceDeleteProtectedRecords(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		records     []api.CreateRecordOpts
+		allowChange bool
+		wantErr     bool
+	}{
+		{name: "no protected records"},
+		{name: "unprotected records", records: []api.CreateRecordOpts{{Type: "MX", Name: "mail", Value: "10 mail.example.com."}}},
+		{name: "protected record", records: []api.CreateRecordOpts{{Type: "MX", Name: "@", Value: "10 mail.example.com."}}, wantErr: true},
+		{name: "allowed", records: []api.CreateRecordOpts{{Type: "MX", Name: "@", Value: "10 mail.example.com."}}, allowChange: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			provider, server, zone := newFaultTestProvider(t, 1)
+			provider.policy.ProtectedRecords = []policy.ProtectedRecord{{Name: "@", Type: "MX"}}
+			r := &zoneResource{provider: provider}
+			schema := testResourceSchema(t, r).Schema
+
+			for _, record := range tc.records {
+				record.ZoneID = zone.ID
+
+				_, err := provider.apiClient.CreateRecord(ctx, record)
+				require.NoError(t, err)
+			}
+
+			state := tfsdk.State{Schema: schema}
+			require.False(t, state.Set(ctx, &zoneResourceModel{
+				ID:                       types.StringValue(zone.ID),
+				Name:                     types.StringValue(zone.Name),
+				TTL:                      types.Int64Value(zone.TTL),
+				NS:                       types.ListNull(types.StringType),
+				AdoptExisting:            types.BoolValue(false),
+				DeleteProtection:         types.BoolValue(false),
+				PreventDeleteWithRecords: types.BoolValue(false),
+				AllowProtectedChange:     types.BoolValue(tc.allowChange),
+				Timeouts:                 nullTimeouts(),
+			}).HasError())
+
+			resp := resource.DeleteResponse{State: state}
+			r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+
+			_, exists := server.ZoneByName(zone.Name)
+
+			if tc.wantErr {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, "Protected Record", resp.Diagnostics.Errors()[0].Summary())
+				assert.True(t, exists)
+
+				return
+			}
+
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.False(t, exists)
+		})
+	}
+}
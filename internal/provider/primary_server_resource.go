@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"strconv"
 	"time"
 
 	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api"
@@ -18,13 +20,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
 	_ resource.Resource                = &primaryServerResource{}
 	_ resource.ResourceWithImportState = &primaryServerResource{}
+	_ resource.ResourceWithModifyPlan  = &primaryServerResource{}
 )
 
 func NewPrimaryServerResource() resource.Resource {
@@ -38,10 +40,11 @@ type primaryServerResource struct {
 
 // primaryServerResourceModel describes the resource data model.
 type primaryServerResourceModel struct {
-	ID      types.String `tfsdk:"id"`
-	Address types.String `tfsdk:"address"`
-	Port    types.Int64  `tfsdk:"port"`
-	ZoneID  types.String `tfsdk:"zone_id"`
+	ID       types.String `tfsdk:"id"`
+	Address  types.String `tfsdk:"address"`
+	Port     types.Int64  `tfsdk:"port"`
+	ZoneID   types.String `tfsdk:"zone_id"`
+	ZoneName types.String `tfsdk:"zone_name"`
 
 	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
@@ -71,11 +74,21 @@ func (r *primaryServerResource) Schema(ctx context.Context, _ resource.SchemaReq
 				},
 			},
 			"zone_id": schema.StringAttribute{
-				MarkdownDescription: "Zone identifier",
-				Required:            true,
+				MarkdownDescription: "Zone identifier. Exactly one of `zone_id` and `zone_name` must be set.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("zone_name")),
+				},
+			},
+			"zone_name": schema.StringAttribute{
+				MarkdownDescription: zoneNameDescription,
+				Optional:            true,
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
 				},
@@ -136,6 +149,9 @@ func (r *primaryServerResource) Configure(_ context.Context, req resource.Config
 func (r *primaryServerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Trace(ctx, "creating primary server")
 
+	ctx, span := r.provider.startSpan(ctx, "hetznerdns_primary_server Create")
+	defer r.provider.endSpan(ctx, span, &resp.Diagnostics)
+
 	var plan primaryServerResourceModel
 
 	// Read Terraform plan into the model
@@ -152,10 +168,16 @@ func (r *primaryServerResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	plan.ZoneID, diags = r.provider.resolveZoneID(ctx, plan.ZoneID, plan.ZoneName)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var (
-		err     error
-		server  *api.PrimaryServer
-		retries int64
+		err    error
+		server *api.PrimaryServer
 	)
 
 	serverRequest := api.CreatePrimaryServerRequest{
@@ -164,19 +186,16 @@ func (r *primaryServerResource) Create(ctx context.Context, req resource.CreateR
 		Port:    plan.Port.ValueInt64(),
 	}
 
-	err = retry.RetryContext(ctx, createTimeout, func() *retry.RetryError {
-		retries++
+	ctx = api.WithAuditInfo(ctx, api.AuditInfo{ResourceType: "hetznerdns_primary_server", ZoneID: plan.ZoneID.ValueString()})
 
+	err = r.provider.retryCreate(ctx, createTimeout, func(ctx context.Context) error {
 		server, err = r.provider.apiClient.CreatePrimaryServer(ctx, serverRequest)
-		if err != nil {
-			if retries == r.provider.maxRetries {
-				return retry.NonRetryableError(err)
-			}
 
-			return retry.RetryableError(err)
-		}
+		return err
+	}, func(ctx context.Context) (bool, error) {
+		server, err = r.findPrimaryServer(ctx, serverRequest)
 
-		return nil
+		return server != nil, err
 	})
 	if err != nil {
 		resp.Diagnostics.AddError("API Error", fmt.Sprintf("creating primary server: %s", err))
@@ -193,6 +212,9 @@ func (r *primaryServerResource) Create(ctx context.Context, req resource.CreateR
 func (r *primaryServerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	tflog.Trace(ctx, "reading primary server")
 
+	ctx, span := r.provider.startSpan(ctx, "hetznerdns_primary_server Read")
+	defer r.provider.endSpan(ctx, span, &resp.Diagnostics)
+
 	var state primaryServerResourceModel
 
 	// Read Terraform prior state into the model
@@ -210,24 +232,14 @@ func (r *primaryServerResource) Read(ctx context.Context, req resource.ReadReque
 	}
 
 	var (
-		err     error
-		server  *api.PrimaryServer
-		retries int64
+		err    error
+		server *api.PrimaryServer
 	)
 
-	err = retry.RetryContext(ctx, readTimeout, func() *retry.RetryError {
-		retries++
-
+	err = r.provider.retry(ctx, readTimeout, func(ctx context.Context) error {
 		server, err = r.provider.apiClient.GetPrimaryServer(ctx, state.ID.ValueString())
-		if err != nil {
-			if retries == r.provider.maxRetries {
-				return retry.NonRetryableError(err)
-			}
-
-			return retry.RetryableError(err)
-		}
 
-		return nil
+		return err
 	})
 	if err != nil && !errors.Is(err, api.ErrNotFound) {
 		resp.Diagnostics.AddError("API Error", fmt.Sprintf("read primary server: %s", err))
@@ -253,6 +265,9 @@ func (r *primaryServerResource) Read(ctx context.Context, req resource.ReadReque
 func (r *primaryServerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Trace(ctx, "updating primary server")
 
+	ctx, span := r.provider.startSpan(ctx, "hetznerdns_primary_server Update")
+	defer r.provider.endSpan(ctx, span, &resp.Diagnostics)
+
 	var plan, state primaryServerResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -270,10 +285,7 @@ func (r *primaryServerResource) Update(ctx context.Context, req resource.UpdateR
 			return
 		}
 
-		var (
-			err     error
-			retries int64
-		)
+		var err error
 
 		server := api.PrimaryServer{
 			ID:      state.ID.ValueString(),
@@ -282,19 +294,12 @@ func (r *primaryServerResource) Update(ctx context.Context, req resource.UpdateR
 			ZoneID:  plan.ZoneID.ValueString(),
 		}
 
-		err = retry.RetryContext(ctx, updateTimeout, func() *retry.RetryError {
-			retries++
-
-			_, err = r.provider.apiClient.UpdatePrimaryServer(ctx, server)
-			if err != nil {
-				if retries == r.provider.maxRetries {
-					return retry.NonRetryableError(err)
-				}
+		ctx = api.WithAuditInfo(ctx, primaryServerAuditInfo(state))
 
-				return retry.RetryableError(err)
-			}
+		err = r.provider.retry(ctx, updateTimeout, func(ctx context.Context) error {
+			_, err := r.provider.apiClient.UpdatePrimaryServer(ctx, server)
 
-			return nil
+			return err
 		})
 		if err != nil {
 			resp.Diagnostics.AddError("API Error", fmt.Sprintf("update primary server %s: %s", state.ID, err))
@@ -310,6 +315,9 @@ func (r *primaryServerResource) Update(ctx context.Context, req resource.UpdateR
 func (r *primaryServerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Trace(ctx, "deleting resource record")
 
+	ctx, span := r.provider.startSpan(ctx, "hetznerdns_primary_server Delete")
+	defer r.provider.endSpan(ctx, span, &resp.Diagnostics)
+
 	var state primaryServerResourceModel
 
 	// Read Terraform prior state into the model
@@ -326,24 +334,18 @@ func (r *primaryServerResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
-	var (
-		err     error
-		retries int64
-	)
+	resp.Diagnostics.Append(r.provider.deletes.reserve("hetznerdns_primary_server", state.ID.ValueString(), state.ZoneID.ValueString())...)
 
-	err = retry.RetryContext(ctx, deleteTimeout, func() *retry.RetryError {
-		retries++
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		err = r.provider.apiClient.DeletePrimaryServer(ctx, state.ID.ValueString())
-		if err != nil {
-			if retries == r.provider.maxRetries {
-				return retry.NonRetryableError(err)
-			}
+	var err error
 
-			return retry.RetryableError(err)
-		}
+	ctx = api.WithAuditInfo(ctx, primaryServerAuditInfo(state))
 
-		return nil
+	err = r.provider.retry(ctx, deleteTimeout, func(ctx context.Context) error {
+		return r.provider.apiClient.DeletePrimaryServer(ctx, state.ID.ValueString())
 	})
 	if err != nil {
 		resp.Diagnostics.AddError("API Error", fmt.Sprintf("deleting primary server %s: %s", state.ID, err))
@@ -352,6 +354,52 @@ func (r *primaryServerResource) Delete(ctx context.Context, req resource.DeleteR
 	}
 }
 
+// findPrimaryServer returns the primary server of the zone matching the address and port of the request, or nil if there is none.
+func (r *primaryServerResource) findPrimaryServer(ctx context.Context, req api.CreatePrimaryServerRequest) (*api.PrimaryServer, error) {
+	servers, err := r.provider.apiClient.GetPrimaryServers(ctx, req.ZoneID)
+	if err != nil {
+		return nil, fmt.Errorf("looking up primary server: %w", err)
+	}
+
+	for _, server := range servers {
+		if server.Address == req.Address && server.Port == req.Port {
+			return &server, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ModifyPlan rejects all changes if the provider is read-only, counts destroys and replacements against the delete budget
+// and resolves the zone_name.
+func (r *primaryServerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(r.provider.checkReadOnly(req, "hetznerdns_primary_server")...)
+	resp.Diagnostics.Append(r.provider.planDelete(ctx, req, "hetznerdns_primary_server", path.Root("zone_id"))...)
+
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() || r.provider == nil {
+		return
+	}
+
+	_, diags := r.provider.planZoneID(ctx, req, resp)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.provider.planReplace(ctx, req, resp, "hetznerdns_primary_server", path.Root("zone_id"))...)
+}
+
 func (r *primaryServerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// primaryServerAuditInfo describes the primary server in the state for the audit log.
+func primaryServerAuditInfo(state primaryServerResourceModel) api.AuditInfo {
+	return api.AuditInfo{
+		ResourceType: "hetznerdns_primary_server",
+		ResourceID:   state.ID.ValueString(),
+		ZoneID:       state.ZoneID.ValueString(),
+		OldValue:     net.JoinHostPort(state.Address.ValueString(), strconv.FormatInt(state.Port.ValueInt64(), 10)),
+	}
+}
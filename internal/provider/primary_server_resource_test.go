@@ -11,7 +11,6 @@ import (
 
 	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api"
 	"github.com/germanbrew/terraform-provider-hetznerdns/internal/utils"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
@@ -206,9 +205,9 @@ func TestAccPrimaryServer_StalePrimaryServersResources(t *testing.T) {
 					)
 
 					apiToken = utils.ConfigureStringAttribute(data.ApiToken, "HETZNER_DNS_TOKEN", "")
-					httpClient := logging.NewLoggingHTTPTransport(http.DefaultTransport)
+					httpClient := api.NewLoggingTransport(http.DefaultTransport, false)
 
-					apiClient, err = api.New("https://dns.hetzner.com", apiToken, httpClient)
+					apiClient, err = api.New(testAccAPIEndpoint, apiToken, httpClient)
 					if err != nil {
 						t.Fatalf("Error while creating API apiClient: %s", err)
 					}
@@ -4,21 +4,30 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
-	"os"
+	"net/url"
+	"sort"
+	"strings"
 
 	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api"
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/policy"
 	"github.com/germanbrew/terraform-provider-hetznerdns/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Ensure ScaffoldingProvider satisfies various provider interfaces.
@@ -27,22 +36,59 @@ var (
 	_ provider.ProviderWithFunctions = &hetznerDNSProvider{}
 )
 
+var errInvalidAPIEndpoint = errors.New("invalid API endpoint")
+
+// defaultAPIEndpoint is the base URL of the Hetzner DNS API.
+const defaultAPIEndpoint = "https://dns.hetzner.com"
+
 type hetznerDNSProvider struct {
-	version string
+	version     string
+	apiEndpoint string
 }
 
 type hetznerDNSProviderModel struct {
-	ApiToken           types.String `tfsdk:"api_token"`
-	MaxRetries         types.Int64  `tfsdk:"max_retries"`
-	EnableTxtFormatter types.Bool   `tfsdk:"enable_txt_formatter"`
-	EnableIPValidation types.Bool   `tfsdk:"enable_ip_validation"`
+	ApiToken                  types.String           `tfsdk:"api_token"`
+	APITokenFile              types.String           `tfsdk:"api_token_file"`
+	APITokenCommand           types.List             `tfsdk:"api_token_command"`
+	APIEndpoint               types.String           `tfsdk:"api_endpoint"`
+	HTTPProxy                 types.String           `tfsdk:"http_proxy"`
+	CABundle                  types.String           `tfsdk:"ca_bundle"`
+	InsecureSkipVerify        types.Bool             `tfsdk:"insecure_skip_verify"`
+	ClientCertificate         types.String           `tfsdk:"client_certificate"`
+	ClientKey                 types.String           `tfsdk:"client_key"`
+	MaxRetries                types.Int64            `tfsdk:"max_retries"`
+	Retry                     *retryModel            `tfsdk:"retry"`
+	EnableTxtFormatter        types.Bool             `tfsdk:"enable_txt_formatter"`
+	EnableIPValidation        types.Bool             `tfsdk:"enable_ip_validation"`
+	EnableValueValidation     types.Bool             `tfsdk:"enable_value_validation"`
+	BackupDir                 types.String           `tfsdk:"backup_dir"`
+	ReadOnly                  types.Bool             `tfsdk:"read_only"`
+	AuditLogPath              types.String           `tfsdk:"audit_log_path"`
+	LogHTTPBodies             types.Bool             `tfsdk:"log_http_bodies"`
+	SkipCredentialsValidation types.Bool             `tfsdk:"skip_credentials_validation"`
+	DefaultRecordTTL          types.Int64            `tfsdk:"default_record_ttl"`
+	MinTTL                    types.Int64            `tfsdk:"min_ttl"`
+	MaxTTL                    types.Int64            `tfsdk:"max_ttl"`
+	Policy                    *policyModel           `tfsdk:"policy"`
+	ProtectedRecords          []protectedRecordModel `tfsdk:"protected_records"`
+	MaxDeletesPerRun          types.Int64            `tfsdk:"max_deletes_per_run"`
+	MaxDeletesPerZone         types.Int64            `tfsdk:"max_deletes_per_zone"`
 }
 
 type providerClient struct {
-	apiClient    *api.Client
-	maxRetries   int64
-	txtFormatter bool
-	ipValidation bool
+	apiClient       *api.Client
+	retryConfig     retryConfig
+	txtFormatter    bool
+	valueValidation bool
+	cache           *zoneCache
+	backup          *zoneBackup
+	readOnly        bool
+	skipPlanLookups bool
+	ttl             ttlConfig
+	policy          policy.Policy
+	deletes         *deleteBudget
+	tracerProvider  *sdktrace.TracerProvider
+	traceParent     trace.SpanContext
 }
 
 func (p *hetznerDNSProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -61,26 +107,266 @@ func (p *hetznerDNSProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 					"The old env variable `HETZNER_DNS_API_TOKEN` is deprecated and will be removed in a future release.",
 				Optional:  true,
 				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("api_token_file"), path.MatchRoot("api_token_command")),
+				},
+			},
+			"api_token_file": schema.StringAttribute{
+				Description: "The path to a file containing the Hetzner DNS API token, e.g. a secret mounted by the CI runner. Leading and " +
+					"trailing whitespace is removed. Conflicts with `api_token` and `api_token_command`. Attributes take precedence over env " +
+					"variables, and `HETZNER_DNS_TOKEN` over the token file. You can pass it using the env variable `HETZNER_DNS_TOKEN_FILE` as well.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("api_token_command")),
+				},
+			},
+			"api_token_command": schema.ListAttribute{
+				Description: "A command printing the Hetzner DNS API token, given as the program and its arguments, e.g. " +
+					"`[\"vault\", \"kv\", \"get\", \"-field=token\", \"secret/hetznerdns\"]`. The command isn't run by a shell and " +
+					"has to finish within 30 seconds. Leading and trailing whitespace is removed from its output. Conflicts with `api_token` " +
+					"and `api_token_file`. Attributes take precedence over env variables, and `HETZNER_DNS_TOKEN` and `HETZNER_DNS_TOKEN_FILE` " +
+					"over the token command. You can pass it using the env variable `HETZNER_DNS_TOKEN_COMMAND` as well, with the arguments " +
+					"separated by spaces.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"api_endpoint": schema.StringAttribute{
+				Description: "`Default: https://dns.hetzner.com` The base URL of the Hetzner DNS API, e.g. to use a mock of the API for testing. " +
+					"You can pass it using the env variable `HETZNER_DNS_API_ENDPOINT` as well.",
+				Optional: true,
+			},
+			"http_proxy": schema.StringAttribute{
+				Description: "The URL of the HTTP proxy used to connect to the API. If not set, the proxy is taken from the env variables " +
+					"`HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY`. You can pass it using the env variable `HETZNER_DNS_HTTP_PROXY` as well.",
+				Optional: true,
+			},
+			"ca_bundle": schema.StringAttribute{
+				Description: "PEM encoded CA certificates or the path to a file containing them, which are trusted in addition to the " +
+					"system certificates, e.g. the CA of a TLS intercepting proxy. You can pass it using the env variable `HETZNER_DNS_CA_BUNDLE` as well.",
+				Optional: true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "`Default: false` Disables the verification of the API server certificate. Use this for testing only. " +
+					"You can pass it using the env variable `HETZNER_DNS_INSECURE_SKIP_VERIFY` as well.",
+				Optional: true,
+			},
+			"client_certificate": schema.StringAttribute{
+				Description: "PEM encoded client certificate or the path to a file containing it, which is used for TLS client authentication. " +
+					"Requires `client_key`. You can pass it using the env variable `HETZNER_DNS_CLIENT_CERTIFICATE` as well.",
+				Optional: true,
+			},
+			"client_key": schema.StringAttribute{
+				Description: "PEM encoded private key of the client certificate or the path to a file containing it. " +
+					"You can pass it using the env variable `HETZNER_DNS_CLIENT_KEY` as well.",
+				Optional:  true,
+				Sensitive: true,
 			},
 			"max_retries": schema.Int64Attribute{
-				Description: "`Default: 1` The maximum number of retries to perform when an API request fails. " +
+				Description: "The maximum number of attempts of an API request, `0` retries until the timeout expires. " +
 					"You can pass it using the env variable `HETZNER_DNS_MAX_RETRIES` as well.",
-				Optional: true,
+				DeprecationMessage: "Use `retry.max_attempts` instead. This attribute will be removed in a future release.",
+				Optional:           true,
 				Validators: []validator.Int64{
 					int64validator.AtLeast(0),
 				},
 			},
 			"enable_txt_formatter": schema.BoolAttribute{
 				Description: "`Default: true` Toggles the automatic formatter for TXT record values. " +
-					"Values greater than 255 bytes get split into multiple quoted chunks " +
-					"([RFC4408](https://datatracker.ietf.org/doc/html/rfc4408#section-3.1.3)). " +
+					"Values get encoded as quoted character-strings of at most 255 bytes each with quotes, backslashes and " +
+					"control characters escaped ([RFC1035](https://datatracker.ietf.org/doc/html/rfc1035#section-5.1), " +
+					"[RFC4408](https://datatracker.ietf.org/doc/html/rfc4408#section-3.1.3)). " +
+					"Unchanged values of existing records which earlier versions of the provider sent as they were, " +
+					"e.g. chunked values like `\"abc\" \"def\"`, are kept as they are stored. " +
 					"You can pass it using the env variable `HETZNER_DNS_ENABLE_TXT_FORMATTER` as well.",
 				Optional: true,
 			},
 			"enable_ip_validation": schema.BoolAttribute{
 				Description: "`Default: true` Toggles the validation of IP addresses in A and AAAA records. " +
 					"You can pass it using the env variable `HETZNER_DNS_ENABLE_IP_VALIDATION` as well.",
+				DeprecationMessage: "Use `policy.validate_ip_addresses` instead. This attribute will be removed in a future release.",
+				Optional:           true,
+			},
+			"enable_value_validation": schema.BoolAttribute{
+				Description: "`Default: true` Toggles the validation of record values at plan time, e.g. the format of MX, SRV, CAA, " +
+					"TLSA and DS records or the length of TXT strings. Validation of A and AAAA records is controlled by `policy.validate_ip_addresses`. " +
+					"You can pass it using the env variable `HETZNER_DNS_ENABLE_VALUE_VALIDATION` as well.",
+				Optional: true,
+			},
+			"default_record_ttl": schema.Int64Attribute{
+				Description: "The TTL of `hetznerdns_record` resources without `ttl`, instead of the TTL of their zone. " +
+					"You can pass it using the env variable `HETZNER_DNS_DEFAULT_RECORD_TTL` as well.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_deletes_per_run": schema.Int64Attribute{
+				Description: "The maximum number of `hetznerdns_record`, `hetznerdns_zone` and `hetznerdns_primary_server` resources " +
+					"deleted by a single apply, including replacements. Further deletes fail, and plans which destroy more resources " +
+					"show a warning. No limit if not set. " +
+					"You can pass it using the env variable `HETZNER_DNS_MAX_DELETES_PER_RUN` as well.",
 				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_deletes_per_zone": schema.Int64Attribute{
+				Description: "Like `max_deletes_per_run`, but the maximum number of resources of a single zone deleted by a single apply. " +
+					"No limit if not set. You can pass it using the env variable `HETZNER_DNS_MAX_DELETES_PER_ZONE` as well.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"min_ttl": schema.Int64Attribute{
+				Description: "The minimum `ttl` of `hetznerdns_record` and `hetznerdns_zone` resources. Plans with a lower TTL fail. " +
+					"You can pass it using the env variable `HETZNER_DNS_MIN_TTL` as well.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_ttl": schema.Int64Attribute{
+				Description: "The maximum `ttl` of `hetznerdns_record` and `hetznerdns_zone` resources. Plans with a higher TTL fail. " +
+					"You can pass it using the env variable `HETZNER_DNS_MAX_TTL` as well.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				Description: "`Default: false` Skips the request which checks the API token while configuring the provider, e.g. to " +
+					"plan without network access to the API. Resources then don't read from the API during plan either: a `zone_id` looked " +
+					"up by `zone_name` is only known if the `zone_name` is unchanged, the `fqdn` and `effective_ttl` of changed records " +
+					"are only known after apply, and records aren't checked against the existing records of the zone. " +
+					"Data sources still read from the API. " +
+					"An invalid API token then fails the first request of a resource. " +
+					"You can pass it using the env variable `HETZNER_DNS_SKIP_CREDENTIALS_VALIDATION` as well.",
+				Optional: true,
+			},
+			"read_only": schema.BoolAttribute{
+				Description: "`Default: false` Refuses all changes, e.g. to run `terraform plan` with a production API token. Plans which " +
+					"would create, update or delete a resource fail and the API client refuses all requests which could change anything. " +
+					"You can pass it using the env variable `HETZNER_DNS_READ_ONLY` as well.",
+				Optional: true,
+			},
+			"backup_dir": schema.StringAttribute{
+				Description: "A directory to which the records of a zone are written as a BIND zone file before the zone is deleted " +
+					"and before its records are first updated or deleted by an apply. Every apply changing a record writes a backup, regardless of " +
+					"the number of changed records, because resources are applied one at a time and the provider can't tell how many records " +
+					"of a zone an apply is going to change. Later changes in the same apply don't write another backup. " +
+					"The files are named after the zone, the time and the operation. The directory is created if it doesn't exist. " +
+					"You can pass it using the env variable `HETZNER_DNS_BACKUP_DIR` as well.",
+				Optional: true,
+			},
+			"audit_log_path": schema.StringAttribute{
+				Description: "A file to which a JSON line is appended for every API request which could change anything, i.e. every POST, " +
+					"PUT and DELETE request. Each line contains the time, the request method and path, the HTTP status, the resource type " +
+					"and ID, the zone, the record name and type and the old and new value. Terraform doesn't pass resource addresses to " +
+					"providers, so resources are identified by their type and ID. The API token is redacted. The file is created if it doesn't " +
+					"exist. You can pass it using the env variable `HETZNER_DNS_AUDIT_LOG_PATH` as well.",
+				Optional: true,
+			},
+			"log_http_bodies": schema.BoolAttribute{
+				Description: "`Default: false` Adds the bodies of API requests and responses to the debug logs of the API requests. " +
+					"The API requests are logged by the `api` subsystem, whose level can be set with the env variable " +
+					"`TF_LOG_PROVIDER_HETZNERDNS_API`. The API token is always redacted. " +
+					"You can pass it using the env variable `HETZNER_DNS_LOG_HTTP_BODIES` as well.",
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"policy": schema.SingleNestedBlock{
+				Description: "Rules for `hetznerdns_record` and `hetznerdns_zone` resources, e.g. to keep the records of teams sharing " +
+					"a zone apart. Resources violating a rule fail at plan time. Record types, values and fully qualified names which depend on " +
+					"resources created in the same run, e.g. a zone or an IP address, fail during apply. " +
+					"The bounds of TTLs are set by `min_ttl` and `max_ttl`.",
+				Attributes: map[string]schema.Attribute{
+					"allowed_record_types": schema.ListAttribute{
+						Description: "The record types which may be used, e.g. `[\"A\", \"AAAA\", \"CNAME\"]`. All types are allowed if not set. " +
+							"You can pass it using the env variable `HETZNER_DNS_POLICY_ALLOWED_RECORD_TYPES` as well, with the types separated by commas.",
+						ElementType: types.StringType,
+						Optional:    true,
+						Validators: []validator.List{
+							listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+						},
+					},
+					"allowed_name_patterns": schema.ListAttribute{
+						Description: "Patterns of which the name of a record relative to its zone must match one, e.g. `[\"team-a-*\"]`. " +
+							"`*` matches any sequence of characters and `?` any single character, the zone apex is `@`. Names are matched " +
+							"case-insensitively. All names are allowed if not set. You can pass it using the env variable " +
+							"`HETZNER_DNS_POLICY_ALLOWED_NAME_PATTERNS` as well, with the patterns separated by commas.",
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"deny_wildcard_records": schema.BoolAttribute{
+						Description: "`Default: false` Rejects wildcard records, i.e. records with a `*` label like `*` or `*.dev`. " +
+							"You can pass it using the env variable `HETZNER_DNS_POLICY_DENY_WILDCARD_RECORDS` as well.",
+						Optional: true,
+					},
+					"deny_private_addresses": schema.BoolAttribute{
+						Description: "`Default: false` Rejects A and AAAA records pointing at private addresses, i.e. the RFC1918 ranges " +
+							"`10.0.0.0/8`, `172.16.0.0/12` and `192.168.0.0/16` and the IPv6 range `fc00::/7`. " +
+							"You can pass it using the env variable `HETZNER_DNS_POLICY_DENY_PRIVATE_ADDRESSES` as well.",
+						Optional: true,
+					},
+					"validate_ip_addresses": schema.BoolAttribute{
+						Description: "`Default: true` Toggles the validation of IP addresses in A and AAAA records. Replaces `enable_ip_validation`, " +
+							"which is used if this isn't set. You can pass it using the env variable `HETZNER_DNS_POLICY_VALIDATE_IP_ADDRESSES` as well.",
+						Optional: true,
+					},
+				},
+			},
+			"protected_records": schema.ListNestedBlock{
+				Description: "Records which must not be changed or deleted, e.g. the MX, SPF and DMARC records of a zone. " +
+					"Updates and deletes of matching `hetznerdns_record` resources and deletes of `hetznerdns_zone` resources " +
+					"containing matching records fail unless `allow_protected_change` is set on the resource. " +
+					"A record matches if it matches all patterns of a block. `*` matches any sequence of characters and " +
+					"`?` any single character. Patterns are matched case-insensitively.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"zone": schema.StringAttribute{
+							Description: "A pattern of the zone name without trailing dot, e.g. `example.com`. All zones match if not set.",
+							Optional:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "A pattern of the record name relative to its zone, e.g. `_dmarc`. The zone apex is `@`.",
+							Required:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "A pattern of the record type, e.g. `MX`. All types match if not set.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"retry": schema.SingleNestedBlock{
+				Description: "Controls the retries of failed API requests. Only transient errors are retried, i.e. rate limited requests, " +
+					"server errors and network errors. Other errors like an invalid API token or an invalid value fail immediately.",
+				Attributes: map[string]schema.Attribute{
+					"max_attempts": schema.Int64Attribute{
+						Description: "`Default: 3` The maximum number of attempts of an API request, including the first one. " +
+							"You can pass it using the env variable `HETZNER_DNS_RETRY_MAX_ATTEMPTS` as well.",
+						Optional: true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"min_backoff": schema.StringAttribute{
+						Description: "`Default: 1s` The time to wait before the first retry, which doubles with every further retry. " +
+							"A random jitter of up to half of the time is applied. Rate limited requests wait at least until the rate limit resets. " +
+							"You can pass it using the env variable `HETZNER_DNS_RETRY_MIN_BACKOFF` as well.",
+						Optional: true,
+					},
+					"max_backoff": schema.StringAttribute{
+						Description: "`Default: 30s` The maximum time to wait between two attempts. " +
+							"You can pass it using the env variable `HETZNER_DNS_RETRY_MAX_BACKOFF` as well.",
+						Optional: true,
+					},
+				},
 			},
 		},
 	}
@@ -92,9 +378,36 @@ func (p *hetznerDNSProvider) Configure(ctx context.Context, req provider.Configu
 		data     hetznerDNSProviderModel
 		apiToken string
 		err      error
+		diags    diag.Diagnostics
 	)
 
-	client := &providerClient{}
+	client := &providerClient{
+		cache: newZoneCache(),
+	}
+
+	ctx = api.WithLogSubsystem(ctx)
+
+	// Values which are only known after apply, e.g. an API token from another resource, defer the
+	// resources and data sources of the provider if Terraform supports it.
+	if unknown := unknownAttributes(ctx, req.Config); len(unknown) > 0 {
+		if req.ClientCapabilities.DeferralAllowed {
+			tflog.Debug(ctx, fmt.Sprintf("deferring the provider configuration because of the unknown attributes %v", unknown))
+
+			resp.Deferred = &provider.Deferred{Reason: provider.DeferredReasonProviderConfigUnknown}
+
+			return
+		}
+
+		for _, name := range unknown {
+			resp.Diagnostics.AddAttributeError(path.Root(name), "Unknown Provider Configuration",
+				fmt.Sprintf("The value of %s is only known after apply, so the provider can't be configured. Set it to a "+
+					"value which is known during the plan or apply its dependencies first with -target. Terraform versions "+
+					"supporting deferred actions defer the resources of the provider instead.", name),
+			)
+		}
+
+		return
+	}
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 
@@ -102,65 +415,182 @@ func (p *hetznerDNSProvider) Configure(ctx context.Context, req provider.Configu
 		return
 	}
 
-	apiToken = utils.ConfigureStringAttribute(data.ApiToken, "HETZNER_DNS_TOKEN", "")
-	// Still support the deprecated env var for now but show a warning if it's used.
-	if apiToken == "" {
-		apiToken = os.Getenv("HETZNER_DNS_API_TOKEN")
-		if apiToken != "" {
-			resp.Diagnostics.AddWarning("Deprecated API Token Environment Variable",
-				"The environment variable `HETZNER_DNS_API_TOKEN` is deprecated and will be removed in a future release. "+
-					"Please use `HETZNER_DNS_TOKEN` instead.",
-			)
+	apiToken, diags = configureAPIToken(ctx, data)
+	resp.Diagnostics.Append(diags...)
+
+	client.retryConfig, diags = configureRetry(data)
+	resp.Diagnostics.Append(diags...)
+
+	client.ttl, diags = configureTTL(data)
+	resp.Diagnostics.Append(diags...)
+
+	client.policy, diags = configurePolicy(ctx, data, client.ttl)
+	resp.Diagnostics.Append(diags...)
+
+	client.deletes, diags = configureDeleteBudget(data)
+	resp.Diagnostics.Append(diags...)
+
+	client.txtFormatter, err = utils.ConfigureBoolAttribute(data.EnableTxtFormatter, "HETZNER_DNS_ENABLE_TXT_FORMATTER", true)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("enable_txt_formatter"), "must be a boolean", err.Error())
+	}
+
+	client.valueValidation, err = utils.ConfigureBoolAttribute(data.EnableValueValidation, "HETZNER_DNS_ENABLE_VALUE_VALIDATION", true)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("enable_value_validation"), "must be a boolean", err.Error())
+	}
+
+	client.readOnly, err = utils.ConfigureBoolAttribute(data.ReadOnly, "HETZNER_DNS_READ_ONLY", false)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("read_only"), "must be a boolean", err.Error())
+	}
+
+	client.backup, err = newZoneBackup(utils.ConfigureStringAttribute(data.BackupDir, "HETZNER_DNS_BACKUP_DIR", ""))
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("backup_dir"), "Invalid Backup Directory", err.Error())
+	}
+
+	var auditLog *api.AuditLog
+	if auditLogPath := utils.ConfigureStringAttribute(data.AuditLogPath, "HETZNER_DNS_AUDIT_LOG_PATH", ""); auditLogPath != "" {
+		auditLog, err = api.NewAuditLog(auditLogPath)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("audit_log_path"), "Invalid Audit Log Path", err.Error())
 		}
 	}
 
-	if apiToken == "" {
-		resp.Diagnostics.AddAttributeError(path.Root("api_token"), "Missing API Token Configuration",
-			"While configuring the client, the API token was not found in the HETZNER_DNS_TOKEN environment variable or client configuration block "+
-				"api_token attribute.",
-		)
+	apiEndpoint := strings.TrimSuffix(utils.ConfigureStringAttribute(data.APIEndpoint, "HETZNER_DNS_API_ENDPOINT", p.apiEndpoint), "/")
+	if err = checkAPIEndpoint(apiEndpoint); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("api_endpoint"), "Invalid API Endpoint", err.Error())
 	}
 
-	client.maxRetries, err = utils.ConfigureInt64Attribute(data.MaxRetries, "HETZNER_DNS_MAX_RETRIES", 1)
+	insecureSkipVerify, err := utils.ConfigureBoolAttribute(data.InsecureSkipVerify, "HETZNER_DNS_INSECURE_SKIP_VERIFY", false)
 	if err != nil {
-		resp.Diagnostics.AddAttributeError(path.Root("max_retries"), "must be an integer", err.Error())
+		resp.Diagnostics.AddAttributeError(path.Root("insecure_skip_verify"), "must be a boolean", err.Error())
 	}
 
-	client.txtFormatter, err = utils.ConfigureBoolAttribute(data.EnableTxtFormatter, "HETZNER_DNS_ENABLE_TXT_FORMATTER", true)
+	skipCredentialsValidation, err := utils.ConfigureBoolAttribute(data.SkipCredentialsValidation, "HETZNER_DNS_SKIP_CREDENTIALS_VALIDATION", false)
 	if err != nil {
-		resp.Diagnostics.AddAttributeError(path.Root("enable_txt_formatter"), "must be a boolean", err.Error())
+		resp.Diagnostics.AddAttributeError(path.Root("skip_credentials_validation"), "must be a boolean", err.Error())
 	}
 
-	client.ipValidation, err = utils.ConfigureBoolAttribute(data.EnableIPValidation, "HETZNER_DNS_ENABLE_IP_VALIDATION", true)
+	logHTTPBodies, err := utils.ConfigureBoolAttribute(data.LogHTTPBodies, "HETZNER_DNS_LOG_HTTP_BODIES", false)
 	if err != nil {
-		resp.Diagnostics.AddAttributeError(path.Root("enable_ip_validation"), "must be a boolean", err.Error())
+		resp.Diagnostics.AddAttributeError(path.Root("log_http_bodies"), "must be a boolean", err.Error())
 	}
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	httpClient := logging.NewLoggingHTTPTransport(http.DefaultTransport)
+	transport, err := api.NewTransport(api.TransportOptions{
+		ProxyURL:           utils.ConfigureStringAttribute(data.HTTPProxy, "HETZNER_DNS_HTTP_PROXY", ""),
+		CABundle:           utils.ConfigureStringAttribute(data.CABundle, "HETZNER_DNS_CA_BUNDLE", ""),
+		InsecureSkipVerify: insecureSkipVerify,
+		ClientCertificate:  utils.ConfigureStringAttribute(data.ClientCertificate, "HETZNER_DNS_CLIENT_CERTIFICATE", ""),
+		ClientKey:          utils.ConfigureStringAttribute(data.ClientKey, "HETZNER_DNS_CLIENT_KEY", ""),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid HTTP Transport Configuration", fmt.Sprintf("Error while creating the HTTP transport: %s", err))
+
+		return
+	}
+
+	if insecureSkipVerify {
+		resp.Diagnostics.AddWarning("Insecure TLS Configuration",
+			"The verification of the API server certificate is disabled by `insecure_skip_verify`. Use this for testing only.",
+		)
+	}
+
+	httpClient := api.NewLoggingTransport(transport, logHTTPBodies)
 
-	client.apiClient, err = api.New("https://dns.hetzner.com", apiToken, httpClient)
+	client.apiClient, err = api.New(apiEndpoint, apiToken, httpClient)
 	if err != nil {
 		resp.Diagnostics.AddError("API error while configuring client", fmt.Sprintf("Error while creating API apiClient: %s", err))
 
 		return
 	}
 
-	client.apiClient.SetUserAgent(fmt.Sprintf("terraform-client-hetznerdns/%s (+https://github.com/germanbrew/terraform-client-hetznerdns) ", p.version))
+	client.apiClient.SetReadOnly(client.readOnly)
+	client.apiClient.SetAuditLog(auditLog)
 
-	if _, err = client.apiClient.GetZones(ctx); err != nil && !errors.Is(err, api.ErrNotFound) {
-		resp.Diagnostics.AddError("API error", fmt.Sprintf("Error while fetching zones: %s", err))
+	tracerProvider, traceParent, err := newTracerProvider(ctx, p.version)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid OpenTelemetry Configuration", fmt.Sprintf("Error while configuring tracing: %s", err))
 
 		return
 	}
 
+	if tracerProvider != nil {
+		client.setTracerProvider(tracerProvider, traceParent)
+	}
+
+	client.apiClient.SetUserAgent(fmt.Sprintf("terraform-client-hetznerdns/%s (+https://github.com/germanbrew/terraform-client-hetznerdns) ", p.version))
+
+	client.skipPlanLookups = skipCredentialsValidation
+
+	if !skipCredentialsValidation {
+		if _, err = client.apiClient.GetZones(ctx); err != nil && !errors.Is(err, api.ErrNotFound) {
+			resp.Diagnostics.AddError("API error", fmt.Sprintf("Error while fetching zones: %s", err))
+
+			return
+		}
+	}
+
 	resp.DataSourceData = client
 	resp.ResourceData = client
 }
 
+// unknownAttributes returns the names of the top level attributes and blocks of the configuration whose values
+// are unknown or contain unknown values, sorted by name.
+func unknownAttributes(ctx context.Context, config tfsdk.Config) []string {
+	if config.Raw.IsFullyKnown() {
+		return nil
+	}
+
+	var unknown []string
+
+	for name := range config.Schema.GetAttributes() {
+		unknown = appendIfUnknown(ctx, unknown, config, name)
+	}
+
+	for name := range config.Schema.GetBlocks() {
+		unknown = appendIfUnknown(ctx, unknown, config, name)
+	}
+
+	sort.Strings(unknown)
+
+	return unknown
+}
+
+func appendIfUnknown(ctx context.Context, unknown []string, config tfsdk.Config, name string) []string {
+	var value attr.Value
+
+	if diags := config.GetAttribute(ctx, path.Root(name), &value); diags.HasError() || value == nil {
+		return unknown
+	}
+
+	tfValue, err := value.ToTerraformValue(ctx)
+	if err != nil || tfValue.IsFullyKnown() {
+		return unknown
+	}
+
+	return append(unknown, name)
+}
+
+// checkAPIEndpoint checks that the API endpoint is an absolute HTTP or HTTPS URL.
+func checkAPIEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("parsing URL: %w", err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: %q must be an absolute http or https URL", errInvalidAPIEndpoint, endpoint)
+	}
+
+	return nil
+}
+
 func (p *hetznerDNSProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewPrimaryServerResource,
@@ -174,6 +604,7 @@ func (p *hetznerDNSProvider) DataSources(_ context.Context) []func() datasource.
 		NewZoneDataSource,
 		NewRecordsDataSource,
 		NewNameserversDataSource,
+		NewAPIStatusDataSource,
 	}
 }
 
@@ -186,7 +617,8 @@ func (p *hetznerDNSProvider) Functions(_ context.Context) []func() function.Func
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &hetznerDNSProvider{
-			version: version,
+			version:     version,
+			apiEndpoint: defaultAPIEndpoint,
 		}
 	}
 }
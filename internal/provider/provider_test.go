@@ -1,13 +1,32 @@
 package provider
 
 import (
+	"context"
+	"maps"
 	"os"
 	"testing"
 
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api/fake"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// fakeAPIToken is the API token accepted by the fake API server.
+const fakeAPIToken = "fake-api-token"
+
+// testAccAPIEndpoint is the API endpoint used during acceptance testing. It points to an in-memory
+// fake of the Hetzner DNS API unless HETZNER_DNS_TOKEN is set to run the tests against the real API.
+//
+//nolint:gochecknoglobals
+var testAccAPIEndpoint = defaultAPIEndpoint
+
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
 // acceptance testing. The factory function will be invoked for every Terraform
 // CLI command executed to create a provider server to which the CLI can
@@ -15,7 +34,30 @@ import (
 //
 //nolint:gochecknoglobals
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"hetznerdns": providerserver.NewProtocol6WithError(New("test")()),
+	"hetznerdns": func() (tfprotov6.ProviderServer, error) {
+		return providerserver.NewProtocol6WithError(&hetznerDNSProvider{
+			version:     "test",
+			apiEndpoint: testAccAPIEndpoint,
+		})()
+	},
+}
+
+func TestMain(m *testing.M) {
+	if os.Getenv("HETZNER_DNS_TOKEN") != "" {
+		os.Exit(m.Run())
+	}
+
+	server := fake.NewServer(fakeAPIToken)
+	testAccAPIEndpoint = server.URL
+
+	if err := os.Setenv("HETZNER_DNS_TOKEN", fakeAPIToken); err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+
+	server.Close()
+	os.Exit(code)
 }
 
 func testAccPreCheck(t *testing.T) {
@@ -23,3 +65,186 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("HETZNER_DNS_TOKEN must be set for acceptance tests")
 	}
 }
+
+// testAccSkipOffline skips tests which need network access beyond the API, e.g. to resolve the
+// nameservers, when running against the fake API.
+func testAccSkipOffline(t *testing.T) {
+	t.Helper()
+
+	if testAccAPIEndpoint != defaultAPIEndpoint {
+		t.Skip("skipping test which requires network access when running against the fake API")
+	}
+}
+
+// testProviderConfig returns a provider configuration with the given attribute values. All other attributes are null.
+func testProviderConfig(t *testing.T, p provider.Provider, values map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+
+	ctx := context.Background()
+
+	var schemaResp provider.SchemaResponse
+
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), schemaResp.Diagnostics)
+
+	objectType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	require.True(t, ok)
+
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, typ := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(typ, nil)
+	}
+
+	for name, value := range values {
+		require.Contains(t, attributes, name)
+
+		attributes[name] = value
+	}
+
+	return tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+}
+
+func TestProviderConfigureTransport(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer(fakeAPIToken, fake.WithTLS())
+	t.Cleanup(server.Close)
+
+	for _, tc := range []struct {
+		name        string
+		values      map[string]tftypes.Value
+		errSummary  string
+		warnSummary string
+	}{
+		{
+			name:       "untrusted certificate",
+			values:     map[string]tftypes.Value{},
+			errSummary: "API error",
+		},
+		{
+			name:   "CA bundle",
+			values: map[string]tftypes.Value{"ca_bundle": tftypes.NewValue(tftypes.String, server.CertificatePEM())},
+		},
+		{
+			name:        "insecure skip verify",
+			values:      map[string]tftypes.Value{"insecure_skip_verify": tftypes.NewValue(tftypes.Bool, true)},
+			warnSummary: "Insecure TLS Configuration",
+		},
+		{
+			name:       "invalid CA bundle",
+			values:     map[string]tftypes.Value{"ca_bundle": tftypes.NewValue(tftypes.String, "-----BEGIN NOTHING-----")},
+			errSummary: "Invalid HTTP Transport Configuration",
+		},
+		{
+			name:       "client certificate without key",
+			values:     map[string]tftypes.Value{"client_certificate": tftypes.NewValue(tftypes.String, server.CertificatePEM())},
+			errSummary: "Invalid HTTP Transport Configuration",
+		},
+		{
+			name:       "invalid API endpoint",
+			values:     map[string]tftypes.Value{"api_endpoint": tftypes.NewValue(tftypes.String, "dns.hetzner.com")},
+			errSummary: "Invalid API Endpoint",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := New("test")()
+
+			values := map[string]tftypes.Value{
+				"api_token":    tftypes.NewValue(tftypes.String, fakeAPIToken),
+				"api_endpoint": tftypes.NewValue(tftypes.String, server.URL+"/"),
+			}
+			maps.Copy(values, tc.values)
+
+			var resp provider.ConfigureResponse
+
+			p.Configure(context.Background(), provider.ConfigureRequest{Config: testProviderConfig(t, p, values)}, &resp)
+
+			if tc.errSummary != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tc.errSummary, resp.Diagnostics.Errors()[0].Summary())
+
+				return
+			}
+
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.NotNil(t, resp.ResourceData)
+
+			if tc.warnSummary != "" {
+				require.Len(t, resp.Diagnostics.Warnings(), 1)
+				assert.Equal(t, tc.warnSummary, resp.Diagnostics.Warnings()[0].Summary())
+			}
+		})
+	}
+}
+
+func TestProviderConfigureUnknownValues(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name            string
+		deferralAllowed bool
+		wantDeferred    bool
+	}{
+		{
+			name:            "deferral allowed",
+			deferralAllowed: true,
+			wantDeferred:    true,
+		},
+		{
+			name: "deferral not allowed",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := New("test")()
+
+			var resp provider.ConfigureResponse
+
+			p.Configure(context.Background(), provider.ConfigureRequest{
+				Config: testProviderConfig(t, p, map[string]tftypes.Value{
+					"api_token":    tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+					"api_endpoint": tftypes.NewValue(tftypes.String, "http://127.0.0.1:1"),
+				}),
+				ClientCapabilities: provider.ConfigureProviderClientCapabilities{DeferralAllowed: tc.deferralAllowed},
+			}, &resp)
+
+			assert.Nil(t, resp.ResourceData)
+
+			if tc.wantDeferred {
+				require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+				require.NotNil(t, resp.Deferred)
+				assert.Equal(t, provider.DeferredReasonProviderConfigUnknown, resp.Deferred.Reason)
+
+				return
+			}
+
+			assert.Nil(t, resp.Deferred)
+			require.Len(t, resp.Diagnostics.Errors(), 1)
+			assert.Equal(t, "Unknown Provider Configuration", resp.Diagnostics.Errors()[0].Summary())
+			assert.Equal(t, path.Root("api_token"), resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath).Path())
+		})
+	}
+}
+
+func TestProviderConfigureSkipCredentialsValidation(t *testing.T) {
+	t.Parallel()
+
+	// Nothing listens on the API endpoint, so only skipping the validation succeeds.
+	for _, skip := range []bool{false, true} {
+		p := New("test")()
+
+		var resp provider.ConfigureResponse
+
+		p.Configure(context.Background(), provider.ConfigureRequest{Config: testProviderConfig(t, p, map[string]tftypes.Value{
+			"api_token":                   tftypes.NewValue(tftypes.String, fakeAPIToken),
+			"api_endpoint":                tftypes.NewValue(tftypes.String, "http://127.0.0.1:1"),
+			"skip_credentials_validation": tftypes.NewValue(tftypes.Bool, skip),
+		})}, &resp)
+
+		assert.Equal(t, !skip, resp.Diagnostics.HasError(), resp.Diagnostics)
+		assert.Equal(t, skip, resp.ResourceData != nil)
+	}
+}
@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// checkReadOnly returns an error if the provider is read-only and the plan would create, update or delete the resource.
+func (p *providerClient) checkReadOnly(req resource.ModifyPlanRequest, typeName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if p == nil || !p.readOnly {
+		return diags
+	}
+
+	var change string
+
+	switch {
+	case req.Plan.Raw.IsNull():
+		change = "deleted"
+	case req.State.Raw.IsNull():
+		change = "created"
+	case !req.Plan.Raw.Equal(req.State.Raw):
+		change = "updated"
+	default:
+		return diags
+	}
+
+	diags.AddError("Read-Only Mode",
+		"The provider is configured with `read_only`, which refuses all changes, but a "+typeName+" would be "+change+". "+
+			"Disable `read_only` to apply changes.",
+	)
+
+	return diags
+}
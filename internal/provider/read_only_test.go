@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZoneResourceModifyPlanReadOnly(t *testing.T) {
+	t.Parallel()
+
+	zone := zoneResourceModel{
+		ID:                       types.StringValue("1"),
+		Name:                     types.StringValue("example.com"),
+		TTL:                      types.Int64Value(3600),
+		NS:                       types.ListNull(types.StringType),
+		AdoptExisting:            types.BoolValue(false),
+		DeleteProtection:         types.BoolValue(false),
+		PreventDeleteWithRecords: types.BoolValue(false),
+		Timeouts:                 nullTimeouts(),
+	}
+
+	updated := zone
+	updated.TTL = types.Int64Value(60)
+
+	for _, tc := range []struct {
+		name     string
+		readOnly bool
+		state    *zoneResourceModel
+		plan     *zoneResourceModel
+		wantErr  string
+	}{
+		{
+			name:     "create",
+			readOnly: true,
+			plan:     &zone,
+			wantErr:  "a hetznerdns_zone would be created",
+		},
+		{
+			name:     "update",
+			readOnly: true,
+			state:    &zone,
+			plan:     &updated,
+			wantErr:  "a hetznerdns_zone would be updated",
+		},
+		{
+			name:     "delete",
+			readOnly: true,
+			state:    &zone,
+			wantErr:  "a hetznerdns_zone would be deleted",
+		},
+		{
+			name:     "no change",
+			readOnly: true,
+			state:    &zone,
+			plan:     &zone,
+		},
+		{
+			name:  "not read-only",
+			state: &zone,
+			plan:  &updated,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			r := &zoneResource{provider: &providerClient{readOnly: tc.readOnly}}
+			schema := testResourceSchema(t, r).Schema
+
+			state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+			if tc.state != nil {
+				require.False(t, state.Set(ctx, tc.state).HasError())
+			}
+
+			plan := tfsdk.Plan{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+			if tc.plan != nil {
+				require.False(t, plan.Set(ctx, tc.plan).HasError())
+			}
+
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: schema, Raw: plan.Raw}, State: state, Plan: plan}, &resp)
+
+			if tc.wantErr == "" {
+				require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+				return
+			}
+
+			require.True(t, resp.Diagnostics.HasError())
+			assert.Equal(t, "Read-Only Mode", resp.Diagnostics.Errors()[0].Summary())
+			assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), tc.wantErr)
+		})
+	}
+}
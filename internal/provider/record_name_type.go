@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = recordNameType{}
+	_ basetypes.StringValuableWithSemanticEquals = recordNameValue{}
+	_ validator.String                           = recordNameValidator{}
+)
+
+// recordNameType is the type of record names. Names which only differ in the spelling
+// of the apex, a trailing dot or their case are semantically equal.
+type recordNameType struct {
+	basetypes.StringType
+}
+
+func (t recordNameType) Equal(o attr.Type) bool {
+	other, ok := o.(recordNameType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t recordNameType) String() string {
+	return "recordNameType"
+}
+
+func (t recordNameType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return recordNameValue{StringValue: in}, nil
+}
+
+func (t recordNameType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
+
+func (t recordNameType) ValueType(_ context.Context) attr.Value {
+	return recordNameValue{}
+}
+
+// recordNameValue is the value of a record name.
+type recordNameValue struct {
+	basetypes.StringValue
+}
+
+func newRecordNameValue(name string) recordNameValue {
+	return recordNameValue{StringValue: basetypes.NewStringValue(name)}
+}
+
+func (v recordNameValue) Equal(o attr.Value) bool {
+	other, ok := o.(recordNameValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v recordNameValue) Type(_ context.Context) attr.Type {
+	return recordNameType{}
+}
+
+func (v recordNameValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(recordNameValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+
+		return false, diags
+	}
+
+	return utils.CanonicalRecordName(v.ValueString()) == utils.CanonicalRecordName(newValue.ValueString()), diags
+}
+
+// recordNameValidator validates the syntax of a record name.
+type recordNameValidator struct{}
+
+func (v recordNameValidator) Description(_ context.Context) string {
+	return "value must be a valid record name"
+}
+
+func (v recordNameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v recordNameValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := utils.CheckRecordName(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid record name", err.Error())
+	}
+}
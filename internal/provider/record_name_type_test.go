@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordNameValueSemanticEquals(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		oldValue string
+		newValue string
+		equal    bool
+	}{
+		{name: "identical", oldValue: "www", newValue: "www", equal: true},
+		{name: "apex and empty", oldValue: "@", newValue: "", equal: true},
+		{name: "trailing dot", oldValue: "www.example.com", newValue: "www.example.com.", equal: true},
+		{name: "case", oldValue: "WWW", newValue: "www", equal: true},
+		{name: "different names", oldValue: "www", newValue: "mail", equal: false},
+		{name: "apex and name", oldValue: "@", newValue: "www", equal: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			equal, diags := newRecordNameValue(tc.oldValue).StringSemanticEquals(context.Background(), newRecordNameValue(tc.newValue))
+
+			require.False(t, diags.HasError())
+			require.Equal(t, tc.equal, equal)
+		})
+	}
+}
@@ -4,28 +4,34 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api"
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/dnsvalidate"
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/policy"
 	"github.com/germanbrew/terraform-provider-hetznerdns/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
-	_ resource.Resource                = &recordResource{}
-	_ resource.ResourceWithImportState = &recordResource{}
+	_ resource.Resource                   = &recordResource{}
+	_ resource.ResourceWithImportState    = &recordResource{}
+	_ resource.ResourceWithModifyPlan     = &recordResource{}
+	_ resource.ResourceWithValidateConfig = &recordResource{}
 )
 
 func NewRecordResource() resource.Resource {
@@ -39,12 +45,17 @@ type recordResource struct {
 
 // recordResourceModel describes the resource data model.
 type recordResourceModel struct {
-	ID     types.String `tfsdk:"id"`
-	ZoneID types.String `tfsdk:"zone_id"`
-	Name   types.String `tfsdk:"name"`
-	Type   types.String `tfsdk:"type"`
-	Value  types.String `tfsdk:"value"`
-	TTL    types.Int64  `tfsdk:"ttl"`
+	ID       types.String    `tfsdk:"id"`
+	ZoneID   types.String    `tfsdk:"zone_id"`
+	ZoneName types.String    `tfsdk:"zone_name"`
+	Name     recordNameValue `tfsdk:"name"`
+	FQDN     types.String    `tfsdk:"fqdn"`
+	Type     types.String    `tfsdk:"type"`
+	Value    types.String    `tfsdk:"value"`
+	TTL      types.Int64     `tfsdk:"ttl"`
+
+	EffectiveTTL         types.Int64 `tfsdk:"effective_ttl"`
+	AllowProtectedChange types.Bool  `tfsdk:"allow_protected_change"`
 
 	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
@@ -60,11 +71,21 @@ func (r *recordResource) Schema(ctx context.Context, _ resource.SchemaRequest, r
 
 		Attributes: map[string]schema.Attribute{
 			"zone_id": schema.StringAttribute{
-				Description: "ID of the DNS zone to create the record in.",
-				Required:    true,
+				Description: "ID of the DNS zone to create the record in. Exactly one of `zone_id` and `zone_name` must be set.",
+				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("zone_name")),
+				},
+			},
+			"zone_name": schema.StringAttribute{
+				MarkdownDescription: zoneNameDescription,
+				Optional:            true,
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
 				},
@@ -81,27 +102,53 @@ func (r *recordResource) Schema(ctx context.Context, _ resource.SchemaRequest, r
 				},
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "Name of the DNS record to create",
-				Required:            true,
+				MarkdownDescription: "Name of the DNS record to create. Use `@` or an empty string for the zone apex. " +
+					"Fully qualified names under the zone (e.g. `www.example.com.`) are converted to names relative to the zone, " +
+					"names outside of the zone are rejected.",
+				Required:   true,
+				CustomType: recordNameType{},
 				Validators: []validator.String{
-					stringvalidator.LengthAtLeast(1),
+					recordNameValidator{},
 				},
 			},
+			"fqdn": schema.StringAttribute{
+				MarkdownDescription: "Fully qualified domain name of the record (e.g. `www.example.com.`)",
+				Computed:            true,
+			},
 			"value": schema.StringAttribute{
-				Description:         "The value of the record (e.g. 192.168.1.1)",
-				MarkdownDescription: "The value of the record (e.g. `192.168.1.1`)",
-				Required:            true,
+				Description: "The value of the record (e.g. 192.168.1.1). Equivalent spellings of a value, " +
+					"e.g. an expanded IPv6 address or a host name with or without trailing dot, are not shown as a difference. " +
+					"Whether two spellings are equivalent depends on the type of the record, so the value is compared when the record " +
+					"is planned and read, and the spelling which is already in the state is kept.",
+				MarkdownDescription: "The value of the record (e.g. `192.168.1.1`). Equivalent spellings of a value, " +
+					"e.g. an expanded IPv6 address or a host name with or without trailing dot, are not shown as a difference. " +
+					"Whether two spellings are equivalent depends on the `type` of the record, so the value is compared when the record " +
+					"is planned and read, and the spelling which is already in the state is kept.",
+				Required: true,
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
 			"ttl": schema.Int64Attribute{
-				MarkdownDescription: "Time to live of this record",
-				Optional:            true,
+				MarkdownDescription: "Time to live of this record. If not set, the `default_record_ttl` of the provider is used, " +
+					"or the record inherits the TTL of its zone",
+				Optional: true,
 				Validators: []validator.Int64{
 					int64validator.AtLeast(0),
 				},
 			},
+			"effective_ttl": schema.Int64Attribute{
+				MarkdownDescription: "The TTL the record is served with, i.e. `ttl` if set, otherwise the `default_record_ttl` " +
+					"of the provider or the TTL of the zone",
+				Computed: true,
+			},
+			"allow_protected_change": schema.BoolAttribute{
+				MarkdownDescription: "`Default: false` Allows to update and delete the record if it matches the `protected_records` " +
+					"of the provider. It has to be enabled and applied before a protected record can be deleted or replaced.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Zone identifier",
@@ -158,6 +205,9 @@ func (r *recordResource) Configure(_ context.Context, req resource.ConfigureRequ
 func (r *recordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Trace(ctx, "create resource record")
 
+	ctx, span := r.provider.startSpan(ctx, "hetznerdns_record Create")
+	defer r.provider.endSpan(ctx, span, &resp.Diagnostics)
+
 	var plan recordResourceModel
 
 	// Read Terraform plan into the model
@@ -174,50 +224,57 @@ func (r *recordResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	value := plan.Value.ValueString()
-	if plan.Type.ValueString() == "TXT" && r.provider.txtFormatter {
-		value = utils.PlainToTXTRecordValue(value)
-		if plan.Value.ValueString() != value {
-			tflog.Debug(ctx, fmt.Sprintf("split TXT record value %d chunks: %q", len(value), value))
-		}
+	value := r.apiValue(plan)
+	if value != plan.Value.ValueString() {
+		tflog.Debug(ctx, fmt.Sprintf("encoded TXT record value: %q", value))
 	}
 
-	if (plan.Type.ValueString() == "A" || plan.Type.ValueString() == "AAAA") && r.provider.ipValidation {
-		err := utils.CheckIPAddress(value)
-		if err != nil {
-			resp.Diagnostics.AddError("Invalid IP address", err.Error())
+	plan.ZoneID, diags = r.provider.resolveZoneID(ctx, plan.ZoneID, plan.ZoneName)
+	resp.Diagnostics.Append(diags...)
 
-			return
-		}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name, fqdn, diags := r.normalizeName(ctx, plan.ZoneID.ValueString(), plan.Name)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fully qualified names are only checked against the policy at plan time if the zone is known,
+	// e.g. if it is created in the same apply. The type and value may also have been unknown.
+	resp.Diagnostics.Append(policyViolation(path.Root("name"), r.provider.policy.CheckRecordName(name))...)
+	resp.Diagnostics.Append(r.checkTypeAndValue(plan.Type, plan.Value)...)
+
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	var (
-		err     error
-		record  *api.Record
-		retries int64
+		err    error
+		record *api.Record
 	)
 
 	recordRequest := api.CreateRecordOpts{
 		ZoneID: plan.ZoneID.ValueString(),
-		Name:   plan.Name.ValueString(),
+		Name:   name,
 		Type:   plan.Type.ValueString(),
 		Value:  value,
-		TTL:    plan.TTL.ValueInt64Pointer(),
+		TTL:    r.apiTTL(plan),
 	}
 
-	err = retry.RetryContext(ctx, createTimeout, func() *retry.RetryError {
-		retries++
+	ctx = api.WithAuditInfo(ctx, api.AuditInfo{ResourceType: "hetznerdns_record", ZoneID: plan.ZoneID.ValueString()})
 
+	err = r.provider.retryCreate(ctx, createTimeout, func(ctx context.Context) error {
 		record, err = r.provider.apiClient.CreateRecord(ctx, recordRequest)
-		if err != nil {
-			if retries == r.provider.maxRetries {
-				return retry.NonRetryableError(err)
-			}
 
-			return retry.RetryableError(err)
-		}
+		return err
+	}, func(ctx context.Context) (bool, error) {
+		record, err = r.findRecord(ctx, recordRequest)
 
-		return nil
+		return record != nil, err
 	})
 	if err != nil {
 		resp.Diagnostics.AddError("API Error", fmt.Sprintf("creating record: %s", err))
@@ -225,7 +282,15 @@ func (r *recordResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	r.provider.cache.Invalidate(plan.ZoneID.ValueString())
+
 	plan.ID = types.StringValue(record.ID)
+	plan.FQDN = types.StringValue(fqdn)
+
+	if plan.EffectiveTTL.IsUnknown() {
+		plan.EffectiveTTL, diags = r.effectiveTTL(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+	}
 
 	// Save plan into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -234,6 +299,9 @@ func (r *recordResource) Create(ctx context.Context, req resource.CreateRequest,
 func (r *recordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	tflog.Trace(ctx, "read resource record")
 
+	ctx, span := r.provider.startSpan(ctx, "hetznerdns_record Read")
+	defer r.provider.endSpan(ctx, span, &resp.Diagnostics)
+
 	var state recordResourceModel
 
 	// Read Terraform prior state into the model
@@ -251,24 +319,14 @@ func (r *recordResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 
 	var (
-		err     error
-		record  *api.Record
-		retries int64
+		err    error
+		record *api.Record
 	)
 
-	err = retry.RetryContext(ctx, readTimeout, func() *retry.RetryError {
-		retries++
-
+	err = r.provider.retry(ctx, readTimeout, func(ctx context.Context) error {
 		record, err = r.provider.apiClient.GetRecord(ctx, state.ID.ValueString())
-		if err != nil {
-			if retries == r.provider.maxRetries {
-				return retry.NonRetryableError(err)
-			}
-
-			return retry.RetryableError(err)
-		}
 
-		return nil
+		return err
 	})
 	if err != nil && !errors.Is(err, api.ErrNotFound) {
 		resp.Diagnostics.AddError("API Error", fmt.Sprintf("read record: %s", err))
@@ -282,17 +340,49 @@ func (r *recordResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	if record.Type == "TXT" && r.provider.txtFormatter {
-		record.Value = utils.TXTRecordToPlainValue(record.Value)
+	// Values written verbatim by earlier versions of the formatter are kept as they are in the state.
+	if record.Type == "TXT" && r.provider.txtFormatter && record.Value != state.Value.ValueString() {
+		record.Value = utils.DecodeTXTRecordValue(record.Value)
+	}
+
+	zone, err := r.provider.cache.Zone(ctx, r.provider.apiClient, record.ZoneID)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("read zone %s: %s", record.ZoneID, err))
+
+		return
+	}
+
+	// Keep the configured spelling of the name as long as it still refers to the same record.
+	name, err := utils.NormalizeRecordName(state.Name.ValueString(), zone.Name)
+	if state.Name.IsNull() || err != nil || !strings.EqualFold(name, record.Name) {
+		state.Name = newRecordNameValue(record.Name)
+	}
+
+	state.FQDN = types.StringValue(utils.RecordFQDN(record.Name, zone.Name))
+	// A TTL set from the default record TTL of the provider is kept unset in the state.
+	if !state.TTL.IsNull() || record.TTL == nil || !state.EffectiveTTL.Equal(types.Int64Value(*record.TTL)) {
+		state.TTL = types.Int64PointerValue(record.TTL)
+	}
+
+	state.EffectiveTTL = types.Int64Value(zone.TTL)
+	if record.TTL != nil {
+		state.EffectiveTTL = types.Int64Value(*record.TTL)
+	}
+
+	// Keep the configured spelling of the value as long as it is equivalent for the type of the record.
+	if state.Value.IsNull() || state.Type.ValueString() != record.Type || !recordValuesEqual(record.Type, state.Value.ValueString(), record.Value) {
+		state.Value = types.StringValue(record.Value)
 	}
 
-	state.Name = types.StringValue(record.Name)
-	state.TTL = types.Int64PointerValue(record.TTL)
 	state.ZoneID = types.StringValue(record.ZoneID)
 	state.Type = types.StringValue(record.Type)
-	state.Value = types.StringValue(record.Value)
 	state.ID = types.StringValue(record.ID)
 
+	// The attribute is not set after an import.
+	if state.AllowProtectedChange.IsNull() {
+		state.AllowProtectedChange = types.BoolValue(false)
+	}
+
 	// Save updated state into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -300,6 +390,9 @@ func (r *recordResource) Read(ctx context.Context, req resource.ReadRequest, res
 func (r *recordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Trace(ctx, "updating resource record")
 
+	ctx, span := r.provider.startSpan(ctx, "hetznerdns_record Update")
+	defer r.provider.endSpan(ctx, span, &resp.Diagnostics)
+
 	var plan, state recordResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -309,21 +402,25 @@ func (r *recordResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	value := plan.Value.ValueString()
-	if plan.Type.ValueString() == "TXT" && r.provider.txtFormatter {
-		value = utils.PlainToTXTRecordValue(value)
+	name, fqdn, diags := r.normalizeName(ctx, plan.ZoneID.ValueString(), plan.Name)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if (plan.Type.ValueString() == "A" || plan.Type.ValueString() == "AAAA") && r.provider.ipValidation {
-		err := utils.CheckIPAddress(value)
-		if err != nil {
-			resp.Diagnostics.AddError("Invalid IP address", err.Error())
+	// The zone, type and value may have been unknown when the record was planned, see Create.
+	resp.Diagnostics.Append(policyViolation(path.Root("name"), r.provider.policy.CheckRecordName(name))...)
+	resp.Diagnostics.Append(r.checkTypeAndValue(plan.Type, plan.Value)...)
 
-			return
-		}
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if !plan.Name.Equal(state.Name) || !plan.TTL.Equal(state.TTL) || !plan.Type.Equal(state.Type) || !plan.Value.Equal(state.Value) {
+	plan.FQDN = types.StringValue(fqdn)
+
+	if !plan.Name.Equal(state.Name) || !plan.TTL.Equal(state.TTL) || !plan.EffectiveTTL.Equal(state.EffectiveTTL) ||
+		!plan.Type.Equal(state.Type) || !plan.Value.Equal(state.Value) {
 		updateTimeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
 		resp.Diagnostics.Append(diags...)
 
@@ -331,39 +428,55 @@ func (r *recordResource) Update(ctx context.Context, req resource.UpdateRequest,
 			return
 		}
 
-		var (
-			err     error
-			retries int64
-		)
+		if !plan.AllowProtectedChange.ValueBool() {
+			resp.Diagnostics.Append(r.checkProtected(ctx, state, "Set `allow_protected_change = true` on the resource to update it.")...)
+
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		resp.Diagnostics.Append(r.provider.backupZone(ctx, updateTimeout, state.ZoneID.ValueString(), backupUpdateRecord)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		value, err := r.updateValue(ctx, updateTimeout, plan, state)
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("update record: %s", err))
+
+			return
+		}
 
 		record := api.Record{
 			ID:     state.ID.ValueString(),
-			Name:   plan.Name.ValueString(),
+			Name:   name,
 			Type:   plan.Type.ValueString(),
 			Value:  value,
-			TTL:    plan.TTL.ValueInt64Pointer(),
+			TTL:    r.apiTTL(plan),
 			ZoneID: plan.ZoneID.ValueString(),
 		}
 
-		err = retry.RetryContext(ctx, updateTimeout, func() *retry.RetryError {
-			retries++
-
-			_, err = r.provider.apiClient.UpdateRecord(ctx, record)
-			if err != nil {
-				if retries == r.provider.maxRetries {
-					return retry.NonRetryableError(err)
-				}
+		ctx = api.WithAuditInfo(ctx, r.auditInfo(ctx, state))
 
-				return retry.RetryableError(err)
-			}
+		err = r.provider.retry(ctx, updateTimeout, func(ctx context.Context) error {
+			_, err := r.provider.apiClient.UpdateRecord(ctx, record)
 
-			return nil
+			return err
 		})
 		if err != nil {
 			resp.Diagnostics.AddError("API Error", fmt.Sprintf("update record: %s", err))
 
 			return
 		}
+
+		r.provider.cache.Invalidate(plan.ZoneID.ValueString())
+	}
+
+	if plan.EffectiveTTL.IsUnknown() {
+		plan.EffectiveTTL, diags = r.effectiveTTL(ctx, plan)
+		resp.Diagnostics.Append(diags...)
 	}
 
 	// Save updated data into Terraform state
@@ -373,6 +486,9 @@ func (r *recordResource) Update(ctx context.Context, req resource.UpdateRequest,
 func (r *recordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Trace(ctx, "deleting resource record")
 
+	ctx, span := r.provider.startSpan(ctx, "hetznerdns_record Delete")
+	defer r.provider.endSpan(ctx, span, &resp.Diagnostics)
+
 	var state recordResourceModel
 
 	// Read Terraform prior state into the model
@@ -389,30 +505,505 @@ func (r *recordResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	var (
-		err     error
-		retries int64
-	)
+	if !state.AllowProtectedChange.ValueBool() {
+		resp.Diagnostics.Append(r.checkProtected(ctx, state,
+			"Set `allow_protected_change = true` on the resource and apply the change before deleting or replacing it.")...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(r.provider.deletes.reserve("hetznerdns_record", state.ID.ValueString(), state.ZoneID.ValueString())...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	err = retry.RetryContext(ctx, deleteTimeout, func() *retry.RetryError {
-		retries++
+	resp.Diagnostics.Append(r.provider.backupZone(ctx, deleteTimeout, state.ZoneID.ValueString(), backupDeleteRecord)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var err error
+
+	ctx = api.WithAuditInfo(ctx, r.auditInfo(ctx, state))
+
+	err = r.provider.retry(ctx, deleteTimeout, func(ctx context.Context) error {
+		return r.provider.apiClient.DeleteRecord(ctx, state.ID.ValueString())
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("deleting record %s: %s", state.ID, err))
+
+		return
+	}
+
+	r.provider.cache.Invalidate(state.ZoneID.ValueString())
+}
+
+// findRecord returns the record of the zone matching the name, type and value of the request, or nil if there is none.
+// The API may store the name in another case or an equivalent spelling of the value.
+func (r *recordResource) findRecord(ctx context.Context, opts api.CreateRecordOpts) (*api.Record, error) {
+	records, err := r.provider.apiClient.GetRecordsByZoneID(ctx, opts.ZoneID)
+	if err != nil {
+		return nil, fmt.Errorf("looking up record: %w", err)
+	}
+
+	for _, record := range *records {
+		if strings.EqualFold(record.Name, opts.Name) && record.Type == opts.Type && recordValuesEqual(opts.Type, record.Value, opts.Value) {
+			return &record, nil
+		}
+	}
 
-		err = r.provider.apiClient.DeleteRecord(ctx, state.ID.ValueString())
+	return nil, nil
+}
+
+// ValidateConfig rejects record names which can't belong to the zone without looking the zone up, validates
+// the record value according to its type and checks the type and value against the policy of the provider,
+// so invalid values fail at plan time instead of being rejected by the API during apply.
+func (r *recordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config recordResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateConfigName(config)...)
+
+	// The validation settings are only known once the provider is configured. Terraform validates
+	// the configuration again during plan, after the provider has been configured.
+	if r.provider == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.checkTypeAndValue(config.Type, config.Value)...)
+}
+
+// checkTypeAndValue checks the type of a record against the policy and validates the value according to the type.
+// Unknown types and values are skipped, so ModifyPlan, Create and Update check them again once they are known.
+func (r *recordResource) checkTypeAndValue(recordType, value types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if recordType.IsNull() || recordType.IsUnknown() {
+		return diags
+	}
+
+	diags.Append(policyViolation(path.Root("type"), r.provider.policy.CheckRecordType(recordType.ValueString()))...)
+
+	if value.IsNull() || value.IsUnknown() {
+		return diags
+	}
+
+	err := r.validateValue(recordType.ValueString(), value.ValueString())
+
+	switch {
+	case errors.Is(err, policy.ErrViolation):
+		diags.Append(policyViolation(path.Root("value"), err)...)
+	case err != nil:
+		diags.AddAttributeError(path.Root("value"), "Invalid record value", err.Error())
+	}
+
+	return diags
+}
+
+// validateConfigName checks the parts of the record name which don't depend on the zone ID, i.e. fully qualified
+// names which can't belong to any zone, names outside of a configured zone_name and CNAME records at the zone apex.
+// Names which depend on the zone ID are checked by ModifyPlan.
+func validateConfigName(config recordResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if config.Name.IsNull() || config.Name.IsUnknown() {
+		return diags
+	}
+
+	if err := utils.CheckRecordNameInAnyZone(config.Name.ValueString()); err != nil {
+		diags.AddAttributeError(path.Root("name"), "Invalid record name", err.Error())
+
+		return diags
+	}
+
+	name := utils.CanonicalRecordName(config.Name.ValueString())
+
+	if !config.ZoneName.IsNull() && !config.ZoneName.IsUnknown() {
+		normalized, err := utils.NormalizeRecordName(config.Name.ValueString(), config.ZoneName.ValueString())
 		if err != nil {
-			if retries == r.provider.maxRetries {
-				return retry.NonRetryableError(err)
-			}
+			diags.AddAttributeError(path.Root("name"), "Invalid record name", err.Error())
+
+			return diags
+		}
+
+		name = utils.CanonicalRecordName(normalized)
+	}
 
-			return retry.RetryableError(err)
+	if config.Type.ValueString() == "CNAME" && name == utils.ApexRecordName {
+		diags.Append(cnameAtApexDiagnostic())
+	}
+
+	return diags
+}
+
+// validateValue checks the value of a record according to the validation settings and the policy of the provider.
+func (r *recordResource) validateValue(recordType, value string) error {
+	switch recordType {
+	case "A", "AAAA":
+		if err := r.provider.policy.CheckRecordValue(recordType, value); err != nil {
+			return fmt.Errorf("validating %s record: %w", recordType, err)
 		}
 
+		if !r.provider.policy.ValidateIPAddresses {
+			return nil
+		}
+	case "TXT":
+		// The formatter splits the value into valid character-strings.
+		if r.provider.txtFormatter {
+			return nil
+		}
+	}
+
+	if !r.provider.valueValidation {
 		return nil
-	})
+	}
+
+	if err := dnsvalidate.Validate(recordType, value); err != nil {
+		return fmt.Errorf("validating %s record: %w", recordType, err)
+	}
+
+	return nil
+}
+
+// ModifyPlan rejects records at plan time which the API would refuse during apply,
+// like CNAME records next to other records of the same name or duplicates of existing records,
+// and records whose name, type, value or TTL violates the policy of the provider. Destroys and replacements are counted
+// against the delete budget.
+func (r *recordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(r.provider.checkReadOnly(req, "hetznerdns_record")...)
+	resp.Diagnostics.Append(r.provider.planDelete(ctx, req, "hetznerdns_record", path.Root("zone_id"))...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Nothing to check if the resource is destroyed or the provider is not configured yet.
+	if req.Plan.Raw.IsNull() || r.provider == nil {
+		return
+	}
+
+	r.checkPlan(ctx, req, resp)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.provider.planReplace(ctx, req, resp, "hetznerdns_record", path.Root("zone_id"))...)
+}
+
+// checkPlan plans the attributes of a record which isn't destroyed and checks it against the API and the policy.
+func (r *recordResource) checkPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var plan, state recordResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the spelling of the value in the state if the configured value is equivalent for the type of the record.
+	if !req.State.Raw.IsNull() && !plan.Value.IsUnknown() && !plan.Value.Equal(state.Value) && plan.Type.Equal(state.Type) &&
+		recordValuesEqual(plan.Type.ValueString(), state.Value.ValueString(), plan.Value.ValueString()) {
+		plan.Value = state.Value
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("value"), state.Value)...)
+	}
+
+	zoneID, diags := r.provider.planZoneID(ctx, req, resp)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ZoneID = zoneID
+
+	if r.provider.skipPlanLookups {
+		// The zone isn't read without plan lookups, so the record is planned like a record of a zone which doesn't exist yet.
+		plan.ZoneID = types.StringUnknown()
+	}
+
+	resp.Diagnostics.Append(r.provider.checkTTLPolicy(path.Root("ttl"), plan.TTL)...)
+
+	// The type or value may have been unknown when the configuration was validated.
+	resp.Diagnostics.Append(r.checkTypeAndValue(plan.Type, plan.Value)...)
+
+	effectiveTTL, diags := r.effectiveTTL(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An unknown TTL keeps the planned value, which is the state of an unchanged record.
+	if !effectiveTTL.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("effective_ttl"), effectiveTTL)...)
+	}
+
+	if plan.Name.IsUnknown() || plan.Type.IsUnknown() {
+		return
+	}
+
+	if plan.ZoneID.IsUnknown() {
+		// Names relative to the zone don't need the zone to be checked against the policy. Fully qualified names
+		// are checked by Create and Update once the zone is known.
+		if !strings.HasSuffix(plan.Name.ValueString(), ".") {
+			name := utils.CanonicalRecordName(plan.Name.ValueString())
+			resp.Diagnostics.Append(policyViolation(path.Root("name"), r.provider.policy.CheckRecordName(name))...)
+		}
+
+		// CNAME records at the apex are rejected by ValidateConfig if the zone isn't known.
+		return
+	}
+
+	name, fqdn, diags := r.normalizeName(ctx, plan.ZoneID.ValueString(), plan.Name)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("fqdn"), fqdn)...)
+	resp.Diagnostics.Append(policyViolation(path.Root("name"), r.provider.policy.CheckRecordName(name))...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Type.ValueString() == "CNAME" && name == utils.ApexRecordName {
+		resp.Diagnostics.Append(cnameAtApexDiagnostic())
+
+		return
+	}
+
+	if plan.Value.IsUnknown() {
+		return
+	}
+
+	var ownID string
+
+	if !req.State.Raw.IsNull() {
+		// Unchanged records have already been accepted by the API.
+		if plan.ZoneID.Equal(state.ZoneID) && plan.Name.Equal(state.Name) && plan.Type.Equal(state.Type) && plan.Value.Equal(state.Value) {
+			return
+		}
+
+		ownID = state.ID.ValueString()
+	}
+
+	records, err := r.provider.cache.Records(ctx, r.provider.apiClient, plan.ZoneID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("deleting record %s: %s", state.ID, err))
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("read records of zone %s: %s", plan.ZoneID.ValueString(), err))
 
 		return
 	}
+
+	value := r.apiValue(plan)
+
+	planned := api.Record{
+		ZoneID: plan.ZoneID.ValueString(),
+		Name:   name,
+		Type:   plan.Type.ValueString(),
+		Value:  value,
+	}
+
+	resp.Diagnostics.Append(checkRecordConflicts(planned, ownID, records)...)
+}
+
+// normalizeName resolves the zone of a record and returns the name of the record
+// in the form the API expects together with its fully qualified domain name.
+func (r *recordResource) normalizeName(ctx context.Context, zoneID string, name recordNameValue) (string, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	zone, err := r.provider.cache.Zone(ctx, r.provider.apiClient, zoneID)
+	if err != nil {
+		diags.AddError("API Error", fmt.Sprintf("read zone %s: %s", zoneID, err))
+
+		return "", "", diags
+	}
+
+	normalized, err := utils.NormalizeRecordName(name.ValueString(), zone.Name)
+	if err != nil {
+		diags.AddAttributeError(path.Root("name"), "Invalid record name", err.Error())
+
+		return "", "", diags
+	}
+
+	return normalized, utils.RecordFQDN(normalized, zone.Name), diags
+}
+
+// checkProtected returns an error if the record in the state matches the protected records of the provider.
+func (r *recordResource) checkProtected(ctx context.Context, state recordResourceModel, detail string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if len(r.provider.policy.ProtectedRecords) == 0 {
+		return diags
+	}
+
+	zone, err := r.provider.cache.Zone(ctx, r.provider.apiClient, state.ZoneID.ValueString())
+	if err != nil {
+		diags.AddError("API Error", fmt.Sprintf("read zone %s: %s", state.ZoneID.ValueString(), err))
+
+		return diags
+	}
+
+	// Names which can't be normalized are matched as they are.
+	name, err := utils.NormalizeRecordName(state.Name.ValueString(), zone.Name)
+	if err != nil {
+		name = state.Name.ValueString()
+	}
+
+	return r.provider.checkProtectedRecord(zone.Name, name, state.Type.ValueString(), detail)
+}
+
+// apiValue returns the value of the record as it is sent to the API.
+func (r *recordResource) apiValue(model recordResourceModel) string {
+	if model.Type.ValueString() == "TXT" && r.provider.txtFormatter {
+		return utils.EncodeTXTRecordValue(model.Value.ValueString())
+	}
+
+	return model.Value.ValueString()
+}
+
+// updateValue returns the value of the record as it is sent to the API by Update. Earlier versions of the formatter
+// sent values made up of quoted character-strings, like chunked DKIM keys, unchanged. Such values are kept as they
+// are stored by the API as long as the value in the state isn't changed.
+func (r *recordResource) updateValue(ctx context.Context, timeout time.Duration, plan, state recordResourceModel) (string, error) {
+	value := r.apiValue(plan)
+
+	if value == plan.Value.ValueString() || !plan.Type.Equal(state.Type) || !plan.Value.Equal(state.Value) ||
+		!utils.IsTXTCharacterStrings(plan.Value.ValueString()) {
+		return value, nil
+	}
+
+	var (
+		err    error
+		record *api.Record
+	)
+
+	err = r.provider.retry(ctx, timeout, func(ctx context.Context) error {
+		record, err = r.provider.apiClient.GetRecord(ctx, state.ID.ValueString())
+
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if record.Value == plan.Value.ValueString() {
+		return record.Value, nil
+	}
+
+	return value, nil
+}
+
+// apiTTL returns the TTL of the record as it is sent to the API, which is the default record TTL
+// of the provider if the record has no TTL.
+func (r *recordResource) apiTTL(model recordResourceModel) *int64 {
+	if model.TTL.IsNull() && r.provider.ttl.defaultRecordTTL > 0 {
+		return &r.provider.ttl.defaultRecordTTL
+	}
+
+	return model.TTL.ValueInt64Pointer()
+}
+
+// effectiveTTL returns the TTL the record is served with. It is unknown if it depends on an unknown
+// TTL or zone.
+func (r *recordResource) effectiveTTL(ctx context.Context, model recordResourceModel) (types.Int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	switch {
+	case model.TTL.IsUnknown():
+		return types.Int64Unknown(), diags
+	case !model.TTL.IsNull():
+		return model.TTL, diags
+	case r.provider.ttl.defaultRecordTTL > 0:
+		return types.Int64Value(r.provider.ttl.defaultRecordTTL), diags
+	case model.ZoneID.IsUnknown():
+		return types.Int64Unknown(), diags
+	}
+
+	zone, err := r.provider.cache.Zone(ctx, r.provider.apiClient, model.ZoneID.ValueString())
+	if err != nil {
+		diags.AddError("API Error", fmt.Sprintf("read zone %s: %s", model.ZoneID.ValueString(), err))
+
+		return types.Int64Unknown(), diags
+	}
+
+	return types.Int64Value(zone.TTL), diags
+}
+
+// auditInfo describes the record in the state for the audit log. The zone name is left out if the zone can't be read.
+func (r *recordResource) auditInfo(ctx context.Context, state recordResourceModel) api.AuditInfo {
+	info := api.AuditInfo{
+		ResourceType: "hetznerdns_record",
+		ResourceID:   state.ID.ValueString(),
+		ZoneID:       state.ZoneID.ValueString(),
+		RecordName:   state.Name.ValueString(),
+		RecordType:   state.Type.ValueString(),
+		OldValue:     r.apiValue(state),
+	}
+
+	if zone, err := r.provider.cache.Zone(ctx, r.provider.apiClient, info.ZoneID); err == nil {
+		info.Zone = zone.Name
+	}
+
+	return info
+}
+
+func cnameAtApexDiagnostic() diag.Diagnostic {
+	return diag.NewAttributeErrorDiagnostic(path.Root("name"), "CNAME record at zone apex",
+		"A CNAME record can't be created at the zone apex (@), because the apex always holds the SOA and NS records of the zone.",
+	)
+}
+
+// checkRecordConflicts checks a planned record against the existing records of its zone.
+// The record with the ID ownID is the one that is being updated or replaced and is ignored.
+func checkRecordConflicts(planned api.Record, ownID string, records []api.Record) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, record := range records {
+		if record.ID == ownID || !strings.EqualFold(record.Name, planned.Name) {
+			continue
+		}
+
+		switch {
+		case planned.Type == "CNAME" && record.Type != "CNAME":
+			diags.AddAttributeError(path.Root("name"), "Conflicting CNAME record",
+				fmt.Sprintf("A CNAME record can't be created with the name %q, because a %s record (ID %s) with the same name already exists in zone %s. "+
+					"A CNAME record must be the only record of its name.", planned.Name, record.Type, record.ID, planned.ZoneID),
+			)
+		case planned.Type != "CNAME" && record.Type == "CNAME":
+			diags.AddAttributeError(path.Root("name"), "Conflicting CNAME record",
+				fmt.Sprintf("A %s record can't be created with the name %q, because a CNAME record (ID %s) with the same name already exists in zone %s. "+
+					"A CNAME record must be the only record of its name.", planned.Type, planned.Name, record.ID, planned.ZoneID),
+			)
+		case planned.Type == "CNAME" && record.Type == "CNAME" && !recordValuesEqual(planned.Type, planned.Value, record.Value):
+			diags.AddAttributeError(path.Root("name"), "Conflicting CNAME record",
+				fmt.Sprintf("A CNAME record can't be created with the name %q, because a CNAME record (ID %s) with the same name and "+
+					"another value already exists in zone %s. A name can only have a single CNAME record.", planned.Name, record.ID, planned.ZoneID),
+			)
+		case planned.Type == record.Type && recordValuesEqual(planned.Type, planned.Value, record.Value):
+			diags.AddAttributeError(path.Root("value"), "Duplicate record",
+				fmt.Sprintf("A %s record with the name %q and the same value already exists in zone %s (ID %s). "+
+					"Import it with `terraform import` instead of creating it again.", planned.Type, planned.Name, planned.ZoneID, record.ID),
+			)
+		}
+	}
+
+	return diags
 }
 
 func (r *recordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -11,10 +11,15 @@ import (
 	"testing"
 
 	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api"
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/dnsvalidate"
 	"github.com/germanbrew/terraform-provider-hetznerdns/internal/utils"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccRecord_Resources(t *testing.T) {
@@ -452,9 +457,9 @@ func TestAccRecord_StaleResources(t *testing.T) {
 					)
 
 					apiToken = utils.ConfigureStringAttribute(data.ApiToken, "HETZNER_DNS_TOKEN", "")
-					httpClient := logging.NewLoggingHTTPTransport(http.DefaultTransport)
+					httpClient := api.NewLoggingTransport(http.DefaultTransport, false)
 
-					apiClient, err = api.New("https://dns.hetzner.com", apiToken, httpClient)
+					apiClient, err = api.New(testAccAPIEndpoint, apiToken, httpClient)
 					if err != nil {
 						t.Fatalf("Error while creating API apiClient: %s", err)
 					}
@@ -626,3 +631,372 @@ func TestAccRecord_UpdateResourceType(t *testing.T) {
 		},
 	})
 }
+
+func TestAccRecord_CNAMEAtApex(t *testing.T) {
+	zoneName := acctest.RandString(10) + ".online"
+	aZoneTTL := 60
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: strings.Join(
+					[]string{
+						testAccZoneResourceConfig("test", zoneName, aZoneTTL),
+						testAccRecordResourceConfig("record1", "@", "CNAME", "example.com."),
+					}, "\n",
+				),
+				ExpectError: regexp.MustCompile("CNAME record at zone apex"),
+			},
+		},
+	})
+}
+
+func TestAccRecord_CNAMEConflict(t *testing.T) {
+	zoneName := acctest.RandString(10) + ".online"
+	aZoneTTL := 60
+	aName := acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: strings.Join(
+					[]string{
+						testAccZoneResourceConfig("test", zoneName, aZoneTTL),
+						testAccRecordResourceConfig("record1", aName, "A", "192.168.1.1"),
+					}, "\n",
+				),
+			},
+			{
+				Config: strings.Join(
+					[]string{
+						testAccZoneResourceConfig("test", zoneName, aZoneTTL),
+						testAccRecordResourceConfig("record1", aName, "A", "192.168.1.1"),
+						testAccRecordResourceConfig("record2", aName, "CNAME", "example.com."),
+					}, "\n",
+				),
+				ExpectError: regexp.MustCompile("Conflicting CNAME record"),
+			},
+		},
+	})
+}
+
+func TestCheckRecordConflicts(t *testing.T) {
+	t.Parallel()
+
+	existing := []api.Record{
+		{ID: "1", ZoneID: "zone", Name: "@", Type: "NS", Value: "hydrogen.ns.hetzner.com."},
+		{ID: "2", ZoneID: "zone", Name: "www", Type: "A", Value: "192.168.1.1"},
+		{ID: "3", ZoneID: "zone", Name: "blog", Type: "CNAME", Value: "www"},
+		{ID: "4", ZoneID: "zone", Name: "_acme", Type: "TXT", Value: "token"},
+	}
+
+	for _, tc := range []struct {
+		name    string
+		planned api.Record
+		ownID   string
+		errors  []string
+	}{
+		{
+			name:    "new record",
+			planned: api.Record{ZoneID: "zone", Name: "mail", Type: "A", Value: "192.168.1.2"},
+		},
+		{
+			name:    "additional record of the same name",
+			planned: api.Record{ZoneID: "zone", Name: "www", Type: "A", Value: "192.168.1.2"},
+		},
+		{
+			name:    "CNAME next to existing record",
+			planned: api.Record{ZoneID: "zone", Name: "www", Type: "CNAME", Value: "example.com."},
+			errors:  []string{"Conflicting CNAME record"},
+		},
+		{
+			name:    "record next to existing CNAME",
+			planned: api.Record{ZoneID: "zone", Name: "blog", Type: "TXT", Value: "test"},
+			errors:  []string{"Conflicting CNAME record"},
+		},
+		{
+			name:    "second CNAME with another value",
+			planned: api.Record{ZoneID: "zone", Name: "blog", Type: "CNAME", Value: "mail"},
+			errors:  []string{"Conflicting CNAME record"},
+		},
+		{
+			name:    "duplicate CNAME",
+			planned: api.Record{ZoneID: "zone", Name: "blog", Type: "CNAME", Value: "www"},
+			errors:  []string{"Duplicate record"},
+		},
+		{
+			name:    "TXT record differing in case",
+			planned: api.Record{ZoneID: "zone", Name: "_acme", Type: "TXT", Value: "Token"},
+		},
+		{
+			name:    "TXT record differing in a trailing dot",
+			planned: api.Record{ZoneID: "zone", Name: "_acme", Type: "TXT", Value: "token."},
+		},
+		{
+			name:    "duplicate TXT record",
+			planned: api.Record{ZoneID: "zone", Name: "_acme", Type: "TXT", Value: "token"},
+			errors:  []string{"Duplicate record"},
+		},
+		{
+			name:    "duplicate record",
+			planned: api.Record{ZoneID: "zone", Name: "www", Type: "A", Value: "192.168.1.1"},
+			errors:  []string{"Duplicate record"},
+		},
+		{
+			name:    "replacing own record with CNAME",
+			planned: api.Record{ZoneID: "zone", Name: "www", Type: "CNAME", Value: "example.com."},
+			ownID:   "2",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := checkRecordConflicts(tc.planned, tc.ownID, existing)
+
+			summaries := make([]string, 0, len(diags))
+			for _, d := range diags {
+				summaries = append(summaries, d.Summary())
+			}
+
+			if len(tc.errors) == 0 {
+				require.Empty(t, summaries)
+			} else {
+				require.Equal(t, tc.errors, summaries)
+			}
+		})
+	}
+}
+
+func TestAccRecord_NameNormalization(t *testing.T) {
+	zoneName := acctest.RandString(10) + ".online"
+	aZoneTTL := 60
+	aName := acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: strings.Join(
+					[]string{
+						testAccZoneResourceConfig("test", zoneName, aZoneTTL),
+						testAccRecordResourceConfig("record1", aName+"."+zoneName+".", "A", "192.168.1.1"),
+						testAccRecordResourceConfig("record2", "", "TXT", "apex"),
+					}, "\n",
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hetznerdns_record.record1", "name", aName+"."+zoneName+"."),
+					resource.TestCheckResourceAttr("hetznerdns_record.record1", "fqdn", aName+"."+zoneName+"."),
+					resource.TestCheckResourceAttr("hetznerdns_record.record2", "name", ""),
+					resource.TestCheckResourceAttr("hetznerdns_record.record2", "fqdn", zoneName+"."),
+				),
+			},
+			{
+				Config: strings.Join(
+					[]string{
+						testAccZoneResourceConfig("test", zoneName, aZoneTTL),
+						testAccRecordResourceConfig("record1", aName+".example.org.", "A", "192.168.1.1"),
+					}, "\n",
+				),
+				ExpectError: regexp.MustCompile(utils.ErrRecordNameOutsideZone.Error()),
+			},
+		},
+	})
+}
+
+func TestAccRecord_InvalidValue(t *testing.T) {
+	zoneName := acctest.RandString(10) + ".online"
+	aZoneTTL := 60
+	aName := acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: strings.Join(
+					[]string{
+						testAccZoneResourceConfig("test", zoneName, aZoneTTL),
+						testAccRecordResourceConfig("record1", aName, "MX", "mail.example.com."),
+					}, "\n",
+				),
+				ExpectError: regexp.MustCompile(dnsvalidate.ErrInvalidValue.Error()),
+			},
+		},
+	})
+}
+
+func TestRecordResourceValidateConfigName(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name       string
+		recordName string
+		recordType string
+		zoneName   types.String
+		wantErr    string
+	}{
+		{name: "relative", recordName: "www", recordType: "A", zoneName: types.StringNull()},
+		{name: "fqdn without zone name", recordName: "www.example.org.", recordType: "A", zoneName: types.StringNull()},
+		{name: "top level domain", recordName: "localhost.", recordType: "A", zoneName: types.StringNull(), wantErr: "Invalid record name"},
+		{name: "fqdn in zone name", recordName: "www.example.com.", recordType: "A", zoneName: types.StringValue("example.com")},
+		{name: "fqdn outside zone name", recordName: "www.example.org.", recordType: "A", zoneName: types.StringValue("example.com"), wantErr: "Invalid record name"},
+		{name: "unknown zone name", recordName: "www.example.org.", recordType: "A", zoneName: types.StringUnknown()},
+		{name: "CNAME at apex", recordName: "@", recordType: "CNAME", zoneName: types.StringNull(), wantErr: "CNAME record at zone apex"},
+		{name: "CNAME at empty name", recordName: "", recordType: "CNAME", zoneName: types.StringNull(), wantErr: "CNAME record at zone apex"},
+		{name: "CNAME at zone name", recordName: "example.com.", recordType: "CNAME", zoneName: types.StringValue("example.com"), wantErr: "CNAME record at zone apex"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			r := &recordResource{}
+			schema := testResourceSchema(t, r).Schema
+
+			plan := tfsdk.Plan{Schema: schema}
+			require.False(t, plan.Set(ctx, &recordResourceModel{
+				ID:                   types.StringNull(),
+				ZoneID:               types.StringNull(),
+				ZoneName:             tc.zoneName,
+				Name:                 newRecordNameValue(tc.recordName),
+				FQDN:                 types.StringNull(),
+				Type:                 types.StringValue(tc.recordType),
+				Value:                types.StringValue("example.net."),
+				TTL:                  types.Int64Null(),
+				EffectiveTTL:         types.Int64Null(),
+				AllowProtectedChange: types.BoolNull(),
+				Timeouts:             nullTimeouts(),
+			}).HasError())
+
+			resp := tfresource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, tfresource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schema, Raw: plan.Raw}}, &resp)
+
+			if tc.wantErr == "" {
+				require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+				return
+			}
+
+			require.Len(t, resp.Diagnostics.Errors(), 1, resp.Diagnostics)
+			assert.Equal(t, tc.wantErr, resp.Diagnostics.Errors()[0].Summary())
+		})
+	}
+}
+
+func TestRecordResourceUpdateChunkedTXTValue(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	provider, server, zone := newFaultTestProvider(t, 1)
+	provider.txtFormatter = true
+	r := &recordResource{provider: provider}
+	schema := testResourceSchema(t, r).Schema
+
+	// A chunked DKIM key as written by earlier versions of the provider.
+	chunked := `"v=DKIM1; k=rsa; p=abc" "def" `
+
+	record, err := provider.apiClient.CreateRecord(ctx, api.CreateRecordOpts{ZoneID: zone.ID, Name: "dkim._domainkey", Type: "TXT", Value: chunked})
+	require.NoError(t, err)
+
+	model := recordResourceModel{
+		ID:                   types.StringValue(record.ID),
+		ZoneID:               types.StringValue(zone.ID),
+		ZoneName:             types.StringNull(),
+		Name:                 newRecordNameValue("dkim._domainkey"),
+		FQDN:                 types.StringValue("dkim._domainkey.example.com."),
+		Type:                 types.StringValue("TXT"),
+		Value:                types.StringValue(chunked),
+		TTL:                  types.Int64Null(),
+		EffectiveTTL:         types.Int64Value(zone.TTL),
+		AllowProtectedChange: types.BoolValue(false),
+		Timeouts:             nullTimeouts(),
+	}
+
+	state := tfsdk.State{Schema: schema}
+	require.False(t, state.Set(ctx, &model).HasError())
+
+	model.TTL = types.Int64Value(60)
+	model.EffectiveTTL = types.Int64Value(60)
+
+	plan := tfsdk.Plan{Schema: schema}
+	require.False(t, plan.Set(ctx, &model).HasError())
+
+	resp := tfresource.UpdateResponse{State: state}
+	r.Update(ctx, tfresource.UpdateRequest{State: state, Plan: plan}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	records := server.Records(zone.ID)
+	require.NotEmpty(t, records)
+
+	for _, updated := range records {
+		if updated.ID == record.ID {
+			assert.Equal(t, chunked, updated.Value)
+			require.NotNil(t, updated.TTL)
+			assert.Equal(t, int64(60), *updated.TTL)
+		}
+	}
+}
+
+func TestRecordResourceQuotedTXTValue(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	provider, server, zone := newFaultTestProvider(t, 1)
+	provider.txtFormatter = true
+	r := &recordResource{provider: provider}
+	schema := testResourceSchema(t, r).Schema
+
+	// A plain value which happens to start and end with a quote.
+	quoted := `"hello"`
+
+	apiValue := func() string {
+		for _, record := range server.Records(zone.ID) {
+			if record.Type == "TXT" {
+				return record.Value
+			}
+		}
+
+		return ""
+	}
+
+	model := recordResourceModel{
+		ID:                   types.StringUnknown(),
+		ZoneID:               types.StringValue(zone.ID),
+		ZoneName:             types.StringNull(),
+		Name:                 newRecordNameValue("_test"),
+		FQDN:                 types.StringUnknown(),
+		Type:                 types.StringValue("TXT"),
+		Value:                types.StringValue(quoted),
+		TTL:                  types.Int64Null(),
+		EffectiveTTL:         types.Int64Unknown(),
+		AllowProtectedChange: types.BoolValue(false),
+		Timeouts:             nullTimeouts(),
+	}
+
+	plan := tfsdk.Plan{Schema: schema}
+	require.False(t, plan.Set(ctx, &model).HasError())
+
+	createResp := tfresource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(ctx, tfresource.CreateRequest{Plan: plan}, &createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+	assert.Equal(t, `"\"hello\""`, apiValue())
+
+	readResp := tfresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, tfresource.ReadRequest{State: createResp.State}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	require.False(t, readResp.State.Get(ctx, &model).HasError())
+	assert.Equal(t, quoted, model.Value.ValueString())
+
+	model.TTL = types.Int64Value(60)
+	model.EffectiveTTL = types.Int64Value(60)
+	require.False(t, plan.Set(ctx, &model).HasError())
+
+	updateResp := tfresource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, tfresource.UpdateRequest{State: readResp.State, Plan: plan}, &updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+	assert.Equal(t, `"\"hello\""`, apiValue())
+}
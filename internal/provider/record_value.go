@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/dnsvalidate"
+)
+
+// recordValuesEqual reports whether two values of a record of the given type are equivalent, i.e. both are
+// valid values of the type and have the same canonical form, e.g. a compressed and an expanded IPv6 address.
+// Values of types without a canonical form like TXT records are compared exactly. It is used by ModifyPlan and
+// Read instead of semantic equality of a custom value type, which only sees the value and not the record type.
+func recordValuesEqual(recordType, a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	switch recordType {
+	case "A", "AAAA", "CNAME", "NS", "PTR", "MX", "SRV", "CAA", "TLSA", "DANE", "DS":
+	default:
+		return false
+	}
+
+	if dnsvalidate.Validate(recordType, a) != nil || dnsvalidate.Validate(recordType, b) != nil {
+		return false
+	}
+
+	return canonicalRecordValue(recordType, a) == canonicalRecordValue(recordType, b)
+}
+
+// canonicalRecordValue returns the canonical form of a valid value of the given record type.
+func canonicalRecordValue(recordType, value string) string {
+	fields := strings.Fields(value)
+
+	switch recordType {
+	case "A", "AAAA":
+		return net.ParseIP(value).String()
+	case "CNAME", "NS", "PTR":
+		return canonicalHostname(value)
+	case "MX":
+		return fields[0] + " " + canonicalHostname(fields[1])
+	case "SRV":
+		return strings.Join(fields[:3], " ") + " " + canonicalHostname(fields[3])
+	case "CAA":
+		parts := strings.SplitN(strings.TrimSpace(value), " ", 3)
+
+		return parts[0] + " " + strings.ToLower(parts[1]) + " " + fmt.Sprintf("%q", strings.Trim(parts[2], `"`))
+	case "TLSA", "DANE", "DS":
+		return strings.Join(fields[:3], " ") + " " + strings.ToLower(strings.Join(fields[3:], ""))
+	}
+
+	return value
+}
+
+func canonicalHostname(name string) string {
+	if name == "." {
+		return name
+	}
+
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordValuesEqual(t *testing.T) {
+	t.Parallel()
+
+	sha256 := strings.Repeat("ab", 32)
+
+	for _, tc := range []struct {
+		name       string
+		recordType string
+		oldValue   string
+		newValue   string
+		equal      bool
+	}{
+		{name: "identical", recordType: "A", oldValue: "192.168.1.1", newValue: "192.168.1.1", equal: true},
+		{name: "different IPv4", recordType: "A", oldValue: "192.168.1.1", newValue: "192.168.1.2", equal: false},
+		{name: "expanded IPv6", recordType: "AAAA", oldValue: "2001:db8::1", newValue: "2001:0db8:0000:0000:0000:0000:0000:0001", equal: true},
+		{name: "upper case IPv6", recordType: "AAAA", oldValue: "2001:db8::a", newValue: "2001:DB8::A", equal: true},
+		{name: "different IPv6", recordType: "AAAA", oldValue: "2001:db8::1", newValue: "2001:db8::2", equal: false},
+		{name: "hostname trailing dot", recordType: "CNAME", oldValue: "www.example.com", newValue: "www.example.com.", equal: true},
+		{name: "hostname case", recordType: "CNAME", oldValue: "WWW.example.com.", newValue: "www.example.com.", equal: true},
+		{name: "different hostnames", recordType: "CNAME", oldValue: "www.example.com.", newValue: "mail.example.com.", equal: false},
+		{name: "MX trailing dot", recordType: "MX", oldValue: "10 mail.example.com", newValue: "10 mail.example.com.", equal: true},
+		{name: "MX different preference", recordType: "MX", oldValue: "10 mail.example.com.", newValue: "20 mail.example.com.", equal: false},
+		{name: "SRV trailing dot", recordType: "SRV", oldValue: "10 0 389 ldap.example.com", newValue: "10 0 389 LDAP.example.com.", equal: true},
+		{name: "CAA quoted", recordType: "CAA", oldValue: `0 issue "letsencrypt.org"`, newValue: `0 issue letsencrypt.org`, equal: true},
+		{name: "CAA tag case", recordType: "CAA", oldValue: `0 ISSUE "letsencrypt.org"`, newValue: `0 issue "letsencrypt.org"`, equal: true},
+		{name: "CAA different value", recordType: "CAA", oldValue: `0 issue "letsencrypt.org"`, newValue: `0 issue "pki.goog"`, equal: false},
+		{name: "TLSA hex case", recordType: "TLSA", oldValue: "3 1 1 " + sha256, newValue: "3 1 1 " + strings.ToUpper(sha256), equal: true},
+		{name: "DS split digest", recordType: "DS", oldValue: "2371 13 2 " + sha256, newValue: "2371 13 2 " + sha256[:32] + " " + sha256[32:], equal: true},
+		{name: "TXT case", recordType: "TXT", oldValue: "v=spf1 -all", newValue: "V=SPF1 -ALL", equal: false},
+		{name: "TXT quotes", recordType: "TXT", oldValue: `"quoted"`, newValue: "quoted", equal: false},
+		{name: "TXT hostname-like case", recordType: "TXT", oldValue: "AbCdEf", newValue: "abcdef", equal: false},
+		{name: "TXT hostname-like trailing dot", recordType: "TXT", oldValue: "token", newValue: "token.", equal: false},
+		{name: "TXT IPv6-like", recordType: "TXT", oldValue: "2001:db8::1", newValue: "2001:DB8::1", equal: false},
+		{name: "unknown type", recordType: "HINFO", oldValue: "token", newValue: "TOKEN.", equal: false},
+		{name: "invalid value of the type", recordType: "A", oldValue: "host.", newValue: "host", equal: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.equal, recordValuesEqual(tc.recordType, tc.oldValue, tc.newValue))
+		})
+	}
+}
+
+func TestRecordResourceReadValueSpelling(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name       string
+		recordType string
+		apiValue   string
+		stateValue string
+		wantValue  string
+	}{
+		{name: "expanded IPv6", recordType: "AAAA", apiValue: "2001:db8::1", stateValue: "2001:0db8::0001", wantValue: "2001:0db8::0001"},
+		{name: "hostname without trailing dot", recordType: "CNAME", apiValue: "www.example.net.", stateValue: "www.example.net", wantValue: "www.example.net"},
+		{name: "TXT case", recordType: "TXT", apiValue: "Token", stateValue: "token", wantValue: "Token"},
+		{name: "TXT trailing dot", recordType: "TXT", apiValue: "token.", stateValue: "token", wantValue: "token."},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			provider, _, zone := newFaultTestProvider(t, 1)
+			r := &recordResource{provider: provider}
+			schema := testResourceSchema(t, r).Schema
+
+			record, err := provider.apiClient.CreateRecord(ctx, api.CreateRecordOpts{ZoneID: zone.ID, Name: "www", Type: tc.recordType, Value: tc.apiValue})
+			require.NoError(t, err)
+
+			state := tfsdk.State{Schema: schema}
+			require.False(t, state.Set(ctx, &recordResourceModel{
+				ID:                   types.StringValue(record.ID),
+				ZoneID:               types.StringValue(zone.ID),
+				ZoneName:             types.StringNull(),
+				Name:                 newRecordNameValue("www"),
+				FQDN:                 types.StringValue("www.example.com."),
+				Type:                 types.StringValue(tc.recordType),
+				Value:                types.StringValue(tc.stateValue),
+				TTL:                  types.Int64Null(),
+				EffectiveTTL:         types.Int64Value(zone.TTL),
+				AllowProtectedChange: types.BoolValue(false),
+				Timeouts:             nullTimeouts(),
+			}).HasError())
+
+			resp := resource.ReadResponse{State: state}
+			r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			var got recordResourceModel
+
+			require.False(t, resp.State.Get(ctx, &got).HasError())
+			assert.Equal(t, tc.wantValue, got.Value.ValueString())
+		})
+	}
+}
+
+func TestRecordResourceModifyPlanValueSpelling(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name       string
+		recordType string
+		stateValue string
+		planValue  string
+		wantValue  string
+	}{
+		{name: "expanded IPv6", recordType: "AAAA", stateValue: "2001:db8::1", planValue: "2001:db8:0:0:0:0:0:1", wantValue: "2001:db8::1"},
+		{name: "hostname spelling", recordType: "CNAME", stateValue: "mail.example.com", planValue: "Mail.Example.com.", wantValue: "mail.example.com"},
+		{name: "CAA quoted", recordType: "CAA", stateValue: `0 issue letsencrypt.org`, planValue: `0 issue "letsencrypt.org"`, wantValue: `0 issue letsencrypt.org`},
+		{name: "different IPv6", recordType: "AAAA", stateValue: "2001:db8::1", planValue: "2001:db8::2", wantValue: "2001:db8::2"},
+		{name: "TXT case", recordType: "TXT", stateValue: "token", planValue: "Token", wantValue: "Token"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			provider, _, zone := newFaultTestProvider(t, 1)
+			r := &recordResource{provider: provider}
+			schema := testResourceSchema(t, r).Schema
+
+			record, err := provider.apiClient.CreateRecord(ctx, api.CreateRecordOpts{ZoneID: zone.ID, Name: "www", Type: tc.recordType, Value: tc.stateValue})
+			require.NoError(t, err)
+
+			model := recordResourceModel{
+				ID:                   types.StringValue(record.ID),
+				ZoneID:               types.StringValue(zone.ID),
+				ZoneName:             types.StringNull(),
+				Name:                 newRecordNameValue("www"),
+				FQDN:                 types.StringValue("www.example.com."),
+				Type:                 types.StringValue(tc.recordType),
+				Value:                types.StringValue(tc.stateValue),
+				TTL:                  types.Int64Null(),
+				EffectiveTTL:         types.Int64Value(zone.TTL),
+				AllowProtectedChange: types.BoolValue(false),
+				Timeouts:             nullTimeouts(),
+			}
+
+			state := tfsdk.State{Schema: schema}
+			require.False(t, state.Set(ctx, &model).HasError())
+
+			model.Value = types.StringValue(tc.planValue)
+			plan := tfsdk.Plan{Schema: schema}
+			require.False(t, plan.Set(ctx, &model).HasError())
+
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: schema, Raw: plan.Raw}, State: state, Plan: plan}, &resp)
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			var got recordResourceModel
+
+			require.False(t, resp.Plan.Get(ctx, &got).HasError())
+			assert.Equal(t, tc.wantValue, got.Value.ValueString())
+		})
+	}
+}
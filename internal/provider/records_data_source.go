@@ -14,8 +14,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -149,22 +147,12 @@ func (d *recordsDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	var (
 		err     error
 		records *[]api.Record
-		retries int64
 	)
 
-	err = retry.RetryContext(ctx, readTimeout, func() *retry.RetryError {
-		retries++
-
+	err = d.provider.retry(ctx, readTimeout, func(ctx context.Context) error {
 		records, err = d.provider.apiClient.GetRecordsByZoneID(ctx, data.ZoneID.ValueString())
-		if err != nil {
-			if retries == d.provider.maxRetries {
-				return retry.NonRetryableError(err)
-			}
-
-			return retry.RetryableError(err)
-		}
 
-		return nil
+		return err
 	})
 	if err != nil {
 		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to get records from zone, got error: %s", err))
@@ -176,12 +164,7 @@ func (d *recordsDataSource) Read(ctx context.Context, req datasource.ReadRequest
 
 	for _, record := range *records {
 		if record.Type == "TXT" && d.provider.txtFormatter {
-			value := utils.TXTRecordToPlainValue(record.Value)
-			if record.Value != value {
-				tflog.Info(ctx, fmt.Sprintf("split TXT record value %d chunks: %q", len(value), value))
-			}
-
-			record.Value = value
+			record.Value = utils.DecodeTXTRecordValue(record.Value)
 		}
 
 		elements = append(elements,
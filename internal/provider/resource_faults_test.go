@@ -0,0 +1,486 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api"
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api/fake"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFaultTestProvider returns a provider client for a fake API with a single zone, which injects the given faults.
+func newFaultTestProvider(t *testing.T, maxAttempts int64, faults ...fake.Fault) (*providerClient, *fake.Server, *api.Zone) {
+	t.Helper()
+
+	provider, server, zone := newFakeTestProvider(t, maxAttempts)
+	server.InjectFaults(faults...)
+
+	return provider, server, zone
+}
+
+// newFakeTestProvider returns a provider client for a fake API with a single zone, which is configured by the given options.
+func newFakeTestProvider(t *testing.T, maxAttempts int64, opts ...fake.Option) (*providerClient, *fake.Server, *api.Zone) {
+	t.Helper()
+
+	server := fake.NewServer(fakeAPIToken, opts...)
+	t.Cleanup(server.Close)
+
+	apiClient, err := api.New(server.URL, fakeAPIToken, http.DefaultTransport)
+	require.NoError(t, err)
+
+	zone, err := apiClient.CreateZone(context.Background(), api.CreateZoneOpts{Name: "example.com", TTL: 3600})
+	require.NoError(t, err)
+
+	retryConfig := retryConfig{maxAttempts: maxAttempts, minBackoff: time.Millisecond, maxBackoff: 10 * time.Millisecond}
+
+	return &providerClient{apiClient: apiClient, retryConfig: retryConfig, cache: newZoneCache()}, server, zone
+}
+
+func nullTimeouts() timeouts.Value {
+	return timeouts.Value{Object: types.ObjectNull(map[string]attr.Type{
+		"create": types.StringType,
+		"read":   types.StringType,
+		"update": types.StringType,
+		"delete": types.StringType,
+	})}
+}
+
+func testResourceSchema(t *testing.T, r resource.Resource) resource.SchemaResponse {
+	t.Helper()
+
+	var resp resource.SchemaResponse
+
+	r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	return resp
+}
+
+func TestRecordResourceCreateFaults(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		faults      []fake.Fault
+		maxAttempts int64
+		wantErr     bool
+		records     int
+	}{
+		{
+			name:        "no fault",
+			maxAttempts: 1,
+			records:     1,
+		},
+		{
+			name:        "rate limited once",
+			faults:      []fake.Fault{{Kind: fake.FaultRateLimit, Method: http.MethodPost}},
+			maxAttempts: 2,
+			records:     1,
+		},
+		{
+			name:        "server error once",
+			faults:      []fake.Fault{{Kind: fake.FaultErrorResponse, Method: http.MethodPost, Status: http.StatusBadGateway}},
+			maxAttempts: 2,
+			records:     1,
+		},
+		{
+			name:        "server error without retries",
+			faults:      []fake.Fault{{Kind: fake.FaultErrorResponse, Method: http.MethodPost}},
+			maxAttempts: 1,
+			wantErr:     true,
+		},
+		{
+			name:        "invalid value is not retried",
+			faults:      []fake.Fault{{Kind: fake.FaultErrorResponse, Method: http.MethodPost, Status: http.StatusUnprocessableEntity}},
+			maxAttempts: 3,
+			wantErr:     true,
+		},
+		{
+			name:        "server errors exhausting retries",
+			faults:      []fake.Fault{{Kind: fake.FaultErrorResponse, Method: http.MethodPost, Times: 2}},
+			maxAttempts: 2,
+			wantErr:     true,
+		},
+		{
+			name:        "dropped connection once",
+			faults:      []fake.Fault{{Kind: fake.FaultDropConnection, Method: http.MethodPost}},
+			maxAttempts: 2,
+			records:     1,
+		},
+		{
+			name:        "slow response",
+			faults:      []fake.Fault{{Kind: fake.FaultSlowResponse, Method: http.MethodPost, Delay: 50 * time.Millisecond}},
+			maxAttempts: 1,
+			records:     1,
+		},
+		// The record is created although the response can't be read, so it is adopted instead of creating a duplicate.
+		{
+			name:        "malformed JSON without retries",
+			faults:      []fake.Fault{{Kind: fake.FaultMalformedJSON, Method: http.MethodPost}},
+			maxAttempts: 1,
+			records:     1,
+		},
+		{
+			name:        "truncated body without retries",
+			faults:      []fake.Fault{{Kind: fake.FaultTruncatedBody, Method: http.MethodPost}},
+			maxAttempts: 1,
+			records:     1,
+		},
+		{
+			name:        "truncated body retried",
+			faults:      []fake.Fault{{Kind: fake.FaultTruncatedBody, Method: http.MethodPost}},
+			maxAttempts: 3,
+			records:     1,
+		},
+		{
+			name: "lookup after truncated body fails once",
+			faults: []fake.Fault{
+				{Kind: fake.FaultTruncatedBody, Method: http.MethodPost},
+				{Kind: fake.FaultErrorResponse, Method: http.MethodGet, Path: "/api/v1/records"},
+			},
+			maxAttempts: 3,
+			records:     1,
+		},
+		{
+			name:        "zone lookup fails",
+			faults:      []fake.Fault{{Kind: fake.FaultErrorResponse, Method: http.MethodGet, Path: "/api/v1/zones/"}},
+			maxAttempts: 2,
+			wantErr:     true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			provider, server, zone := newFaultTestProvider(t, tc.maxAttempts, tc.faults...)
+			r := &recordResource{provider: provider}
+			schema := testResourceSchema(t, r).Schema
+			defaultRecords := len(server.Records(zone.ID))
+
+			plan := tfsdk.Plan{Schema: schema}
+			require.False(t, plan.Set(ctx, &recordResourceModel{
+				ID:       types.StringUnknown(),
+				ZoneID:   types.StringValue(zone.ID),
+				Name:     newRecordNameValue("www"),
+				FQDN:     types.StringUnknown(),
+				Type:     types.StringValue("A"),
+				Value:    types.StringValue("192.0.2.1"),
+				TTL:      types.Int64Null(),
+				Timeouts: nullTimeouts(),
+			}).HasError())
+
+			resp := resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+			r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+
+			require.Equal(t, tc.wantErr, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.Len(t, server.Records(zone.ID), defaultRecords+tc.records)
+
+			if !tc.wantErr {
+				var state recordResourceModel
+
+				require.False(t, resp.State.Get(ctx, &state).HasError())
+				assert.NotEmpty(t, state.ID.ValueString())
+				assert.Equal(t, "www.example.com.", state.FQDN.ValueString())
+			}
+		})
+	}
+}
+
+func TestRecordResourceCreateAdoptsNormalizedRecord(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	provider, server, zone := newFakeTestProvider(t, 1, fake.WithRecordNormalization(func(record *fake.Record) {
+		record.Name = strings.ToLower(record.Name)
+		record.Value = netip.MustParseAddr(record.Value).String()
+	}))
+	r := &recordResource{provider: provider}
+	schema := testResourceSchema(t, r).Schema
+	defaultRecords := len(server.Records(zone.ID))
+
+	// The record is created although the response can't be read, and the API stores another spelling of it.
+	server.InjectFaults(fake.Fault{Kind: fake.FaultTruncatedBody, Method: http.MethodPost})
+
+	plan := tfsdk.Plan{Schema: schema}
+	require.False(t, plan.Set(ctx, &recordResourceModel{
+		ID:       types.StringUnknown(),
+		ZoneID:   types.StringValue(zone.ID),
+		Name:     newRecordNameValue("WWW"),
+		FQDN:     types.StringUnknown(),
+		Type:     types.StringValue("AAAA"),
+		Value:    types.StringValue("2001:0db8:0000:0000:0000:0000:0000:0001"),
+		TTL:      types.Int64Null(),
+		Timeouts: nullTimeouts(),
+	}).HasError())
+
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	records := server.Records(zone.ID)
+	require.Len(t, records, defaultRecords+1)
+
+	var state recordResourceModel
+
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	assert.Equal(t, records[len(records)-1].ID, state.ID.ValueString())
+	assert.Equal(t, "2001:db8::1", records[len(records)-1].Value)
+	assert.Equal(t, "2001:0db8:0000:0000:0000:0000:0000:0001", state.Value.ValueString())
+}
+
+func TestRecordResourceReadFaults(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		faults      []fake.Fault
+		maxAttempts int64
+		recordID    string
+		wantErr     bool
+		removed     bool
+	}{
+		{
+			name:        "no fault",
+			maxAttempts: 1,
+		},
+		{
+			name:        "record deleted",
+			maxAttempts: 1,
+			recordID:    "deleted",
+			removed:     true,
+		},
+		{
+			name:        "rate limited once",
+			faults:      []fake.Fault{{Kind: fake.FaultRateLimit, Path: "/api/v1/records/"}},
+			maxAttempts: 2,
+		},
+		{
+			name:        "malformed JSON is not retried",
+			faults:      []fake.Fault{{Kind: fake.FaultMalformedJSON, Path: "/api/v1/records/"}},
+			maxAttempts: 2,
+			wantErr:     true,
+		},
+		{
+			name:        "truncated body once",
+			faults:      []fake.Fault{{Kind: fake.FaultTruncatedBody, Path: "/api/v1/records/"}},
+			maxAttempts: 2,
+		},
+		{
+			name:        "unauthorized is not retried",
+			faults:      []fake.Fault{{Kind: fake.FaultErrorResponse, Path: "/api/v1/records/", Status: http.StatusUnauthorized}},
+			maxAttempts: 3,
+			wantErr:     true,
+		},
+		{
+			name:        "truncated body without retries",
+			faults:      []fake.Fault{{Kind: fake.FaultTruncatedBody, Path: "/api/v1/records/"}},
+			maxAttempts: 1,
+			wantErr:     true,
+		},
+		{
+			name:        "server error without retries",
+			faults:      []fake.Fault{{Kind: fake.FaultErrorResponse, Path: "/api/v1/records/", Status: http.StatusServiceUnavailable}},
+			maxAttempts: 1,
+			wantErr:     true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			provider, server, zone := newFaultTestProvider(t, tc.maxAttempts)
+			r := &recordResource{provider: provider}
+			schema := testResourceSchema(t, r).Schema
+
+			record, err := provider.apiClient.CreateRecord(ctx, api.CreateRecordOpts{ZoneID: zone.ID, Type: "A", Name: "www", Value: "192.0.2.1"})
+			require.NoError(t, err)
+
+			recordID := record.ID
+			if tc.recordID != "" {
+				recordID = tc.recordID
+			}
+
+			state := tfsdk.State{Schema: schema}
+			require.False(t, state.Set(ctx, &recordResourceModel{
+				ID:       types.StringValue(recordID),
+				ZoneID:   types.StringValue(zone.ID),
+				Name:     newRecordNameValue("www"),
+				FQDN:     types.StringValue("www.example.com."),
+				Type:     types.StringValue("A"),
+				Value:    types.StringValue("192.0.2.1"),
+				TTL:      types.Int64Null(),
+				Timeouts: nullTimeouts(),
+			}).HasError())
+
+			server.InjectFaults(tc.faults...)
+
+			resp := resource.ReadResponse{State: state}
+			r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+
+			require.Equal(t, tc.wantErr, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.Equal(t, tc.removed, resp.State.Raw.IsNull())
+		})
+	}
+}
+
+func TestZoneResourceCreateFaults(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		faults      []fake.Fault
+		maxAttempts int64
+		wantErr     bool
+		created     bool
+	}{
+		{
+			name:        "rate limited once",
+			faults:      []fake.Fault{{Kind: fake.FaultRateLimit, Method: http.MethodPost}},
+			maxAttempts: 2,
+			created:     true,
+		},
+		{
+			name:        "server error without retries",
+			faults:      []fake.Fault{{Kind: fake.FaultErrorResponse, Method: http.MethodPost}},
+			maxAttempts: 1,
+			wantErr:     true,
+		},
+		{
+			name:        "dropped connection once",
+			faults:      []fake.Fault{{Kind: fake.FaultDropConnection, Method: http.MethodPost}},
+			maxAttempts: 2,
+			created:     true,
+		},
+		{
+			name:        "existence check fails",
+			faults:      []fake.Fault{{Kind: fake.FaultErrorResponse, Method: http.MethodGet, Path: "/api/v1/zones"}},
+			maxAttempts: 2,
+			wantErr:     true,
+		},
+		{
+			name:        "truncated body without retries",
+			faults:      []fake.Fault{{Kind: fake.FaultTruncatedBody, Method: http.MethodPost}},
+			maxAttempts: 1,
+			created:     true,
+		},
+		{
+			name:        "malformed JSON retried",
+			faults:      []fake.Fault{{Kind: fake.FaultMalformedJSON, Method: http.MethodPost}},
+			maxAttempts: 2,
+			created:     true,
+		},
+		{
+			name:        "truncated body on every attempt",
+			faults:      []fake.Fault{{Kind: fake.FaultTruncatedBody, Method: http.MethodPost, Times: 3}},
+			maxAttempts: 3,
+			created:     true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			provider, server, _ := newFaultTestProvider(t, tc.maxAttempts, tc.faults...)
+			r := &zoneResource{provider: provider}
+			schema := testResourceSchema(t, r).Schema
+
+			plan := tfsdk.Plan{Schema: schema}
+			require.False(t, plan.Set(ctx, &zoneResourceModel{
+				ID:       types.StringUnknown(),
+				Name:     types.StringValue("example.org"),
+				TTL:      types.Int64Value(3600),
+				NS:       types.ListUnknown(types.StringType),
+				Timeouts: nullTimeouts(),
+			}).HasError())
+
+			resp := resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+			r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+
+			require.Equal(t, tc.wantErr, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			_, created := server.ZoneByName("example.org")
+			assert.Equal(t, tc.created, created)
+
+			if !tc.wantErr {
+				var state zoneResourceModel
+
+				require.False(t, resp.State.Get(ctx, &state).HasError())
+				assert.NotEmpty(t, state.ID.ValueString())
+			}
+		})
+	}
+}
+
+func TestPrimaryServerResourceCreateFaults(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		faults      []fake.Fault
+		maxAttempts int64
+		wantErr     bool
+		servers     int
+	}{
+		{
+			name:        "no fault",
+			maxAttempts: 1,
+			servers:     1,
+		},
+		{
+			name:        "truncated body without retries",
+			faults:      []fake.Fault{{Kind: fake.FaultTruncatedBody, Method: http.MethodPost}},
+			maxAttempts: 1,
+			servers:     1,
+		},
+		{
+			name:        "malformed JSON retried",
+			faults:      []fake.Fault{{Kind: fake.FaultMalformedJSON, Method: http.MethodPost}},
+			maxAttempts: 2,
+			servers:     1,
+		},
+		{
+			name:        "invalid value",
+			faults:      []fake.Fault{{Kind: fake.FaultErrorResponse, Method: http.MethodPost, Status: http.StatusUnprocessableEntity}},
+			maxAttempts: 3,
+			wantErr:     true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			provider, _, zone := newFaultTestProvider(t, tc.maxAttempts, tc.faults...)
+			r := &primaryServerResource{provider: provider}
+			schema := testResourceSchema(t, r).Schema
+
+			plan := tfsdk.Plan{Schema: schema}
+			require.False(t, plan.Set(ctx, &primaryServerResourceModel{
+				ID:       types.StringUnknown(),
+				ZoneID:   types.StringValue(zone.ID),
+				Address:  types.StringValue("192.0.2.53"),
+				Port:     types.Int64Value(53),
+				Timeouts: nullTimeouts(),
+			}).HasError())
+
+			resp := resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+			r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+
+			require.Equal(t, tc.wantErr, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			servers, err := provider.apiClient.GetPrimaryServers(ctx, zone.ID)
+			require.NoError(t, err)
+			assert.Len(t, servers, tc.servers)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api"
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultRetryMaxAttempts = 3
+	defaultRetryMinBackoff  = time.Second
+	defaultRetryMaxBackoff  = 30 * time.Second
+
+	// reconcileTimeout is the timeout of the lookup for an object created by a failed request.
+	reconcileTimeout = 30 * time.Second
+)
+
+type retryModel struct {
+	MaxAttempts types.Int64  `tfsdk:"max_attempts"`
+	MinBackoff  types.String `tfsdk:"min_backoff"`
+	MaxBackoff  types.String `tfsdk:"max_backoff"`
+}
+
+// retryConfig controls how often and how long API requests are retried.
+type retryConfig struct {
+	// maxAttempts is the maximum number of attempts of a request. Zero retries until the timeout expires.
+	maxAttempts int64
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+}
+
+// configureRetry reads the retry block of the provider configuration. The deprecated max_retries attribute
+// is used as the maximum number of attempts if retry.max_attempts is not set.
+func configureRetry(data hetznerDNSProviderModel) (retryConfig, diag.Diagnostics) {
+	var (
+		config retryConfig
+		diags  diag.Diagnostics
+		model  retryModel
+		err    error
+	)
+
+	if data.Retry != nil {
+		model = *data.Retry
+	}
+
+	config.maxAttempts, err = utils.ConfigureInt64Attribute(model.MaxAttempts, "HETZNER_DNS_RETRY_MAX_ATTEMPTS", 0)
+	if err != nil {
+		diags.AddAttributeError(path.Root("retry").AtName("max_attempts"), "must be an integer", err.Error())
+	} else if config.maxAttempts < 0 {
+		diags.AddAttributeError(path.Root("retry").AtName("max_attempts"), "must be at least 1",
+			fmt.Sprintf("The maximum number of attempts must be at least 1, got: %d", config.maxAttempts))
+	}
+
+	if config.maxAttempts == 0 {
+		config.maxAttempts, err = utils.ConfigureInt64Attribute(data.MaxRetries, "HETZNER_DNS_MAX_RETRIES", defaultRetryMaxAttempts)
+		if err != nil {
+			diags.AddAttributeError(path.Root("max_retries"), "must be an integer", err.Error())
+		}
+	}
+
+	config.minBackoff, err = utils.ConfigureDurationAttribute(model.MinBackoff, "HETZNER_DNS_RETRY_MIN_BACKOFF", defaultRetryMinBackoff)
+	if err != nil {
+		diags.AddAttributeError(path.Root("retry").AtName("min_backoff"), "must be a duration", err.Error())
+	}
+
+	config.maxBackoff, err = utils.ConfigureDurationAttribute(model.MaxBackoff, "HETZNER_DNS_RETRY_MAX_BACKOFF", defaultRetryMaxBackoff)
+	if err != nil {
+		diags.AddAttributeError(path.Root("retry").AtName("max_backoff"), "must be a duration", err.Error())
+	}
+
+	if !diags.HasError() && (config.minBackoff < 0 || config.minBackoff > config.maxBackoff) {
+		diags.AddAttributeError(path.Root("retry").AtName("min_backoff"), "Invalid Retry Backoff",
+			fmt.Sprintf("min_backoff (%s) must not be negative or greater than max_backoff (%s)", config.minBackoff, config.maxBackoff))
+	}
+
+	return config, diags
+}
+
+// backoff returns the time to wait before the next attempt after the given number of failed attempts. The
+// backoff doubles with every attempt up to maxBackoff, half of it is randomized to spread concurrent retries.
+func (c retryConfig) backoff(attempts int64) time.Duration {
+	backoff := c.maxBackoff
+	if shift := attempts - 1; shift < 32 && c.minBackoff<<shift < c.maxBackoff {
+		backoff = c.minBackoff << shift
+	}
+
+	if backoff <= 0 {
+		return 0
+	}
+
+	//nolint:gosec // jitter doesn't need a cryptographically secure random number
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+// retry calls fn until it succeeds, fails with an error which is not retryable, the maximum number of
+// attempts is reached or the timeout expires. Rate limited requests wait at least until the rate limit resets.
+func (p *providerClient) retry(ctx context.Context, timeout time.Duration, fn func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for attempts := int64(1); ; attempts++ {
+		err := fn(ctx)
+		if err == nil || !api.IsRetryable(err) || attempts == p.retryConfig.maxAttempts {
+			return err
+		}
+
+		wait := max(p.retryConfig.backoff(attempts), api.RetryAfter(err))
+
+		tflog.Debug(ctx, fmt.Sprintf("retrying API request in %s after attempt %d failed: %s", wait, attempts, err))
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout after %d attempts: %w", attempts, err)
+		case <-time.After(wait):
+		}
+	}
+}
+
+// retryCreate calls create like retry, but makes it idempotent: if an attempt failed ambiguously, the API may
+// have created the object although the response got lost. Before the next attempt and after the last one,
+// find looks up a matching object and reports whether it was found, in which case it is adopted instead of
+// creating a duplicate.
+func (p *providerClient) retryCreate(
+	ctx context.Context, timeout time.Duration, create func(ctx context.Context) error, find func(ctx context.Context) (bool, error),
+) error {
+	var lastErr error
+
+	err := p.retry(ctx, timeout, func(ctx context.Context) error {
+		if api.IsAmbiguous(lastErr) {
+			found, err := find(ctx)
+			if err != nil {
+				return err
+			}
+
+			if found {
+				tflog.Info(ctx, fmt.Sprintf("adopting object created by a previous attempt which failed: %s", lastErr))
+
+				return nil
+			}
+		}
+
+		lastErr = create(ctx)
+
+		return lastErr
+	})
+	if err == nil || !api.IsAmbiguous(lastErr) {
+		return err
+	}
+
+	// The timeout may have expired, so the last lookup gets a short timeout of its own.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), reconcileTimeout)
+	defer cancel()
+
+	if found, findErr := find(ctx); findErr == nil && found {
+		tflog.Info(ctx, fmt.Sprintf("adopting object created by the last attempt which failed: %s", lastErr))
+
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api/fake"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryConfigBackoff(t *testing.T) {
+	t.Parallel()
+
+	config := retryConfig{minBackoff: time.Second, maxBackoff: 10 * time.Second}
+
+	for _, tc := range []struct {
+		attempts int64
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 3, want: 4 * time.Second},
+		{attempts: 4, want: 8 * time.Second},
+		{attempts: 5, want: 10 * time.Second},
+		{attempts: 100, want: 10 * time.Second},
+	} {
+		for range 10 {
+			backoff := config.backoff(tc.attempts)
+			assert.GreaterOrEqual(t, backoff, tc.want/2, "attempt %d", tc.attempts)
+			assert.LessOrEqual(t, backoff, tc.want, "attempt %d", tc.attempts)
+		}
+	}
+
+	assert.Zero(t, retryConfig{}.backoff(1))
+}
+
+func TestProviderClientRetry(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		fault       fake.Fault
+		maxAttempts int64
+		timeout     time.Duration
+		wantErr     string
+		requests    int
+	}{
+		{
+			name:        "success",
+			maxAttempts: 3,
+			requests:    1,
+		},
+		{
+			name:        "server error retried",
+			fault:       fake.Fault{Kind: fake.FaultErrorResponse, Times: 2},
+			maxAttempts: 3,
+			requests:    3,
+		},
+		{
+			name:        "server error exhausting attempts",
+			fault:       fake.Fault{Kind: fake.FaultErrorResponse, Times: 3},
+			maxAttempts: 3,
+			wantErr:     "http status 500 unhandled",
+			requests:    3,
+		},
+		{
+			name:        "rate limit waits for reset",
+			fault:       fake.Fault{Kind: fake.FaultRateLimit, Reset: time.Second},
+			maxAttempts: 2,
+			requests:    2,
+		},
+		{
+			name:        "unauthorized fails fast",
+			fault:       fake.Fault{Kind: fake.FaultErrorResponse, Status: http.StatusUnauthorized, Times: 3},
+			maxAttempts: 3,
+			wantErr:     "HTTP 401 Unauthorized",
+			requests:    1,
+		},
+		{
+			name:        "not found fails fast",
+			fault:       fake.Fault{Kind: fake.FaultErrorResponse, Status: http.StatusNotFound, Times: 3},
+			maxAttempts: 3,
+			wantErr:     "not found",
+			requests:    1,
+		},
+		{
+			name:     "timeout while waiting for rate limit reset",
+			fault:    fake.Fault{Kind: fake.FaultRateLimit, Reset: time.Minute},
+			timeout:  100 * time.Millisecond,
+			wantErr:  "timeout after 1 attempts",
+			requests: 1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			provider, server, zone := newFaultTestProvider(t, tc.maxAttempts, tc.fault)
+			requests := server.Requests()
+
+			timeout := tc.timeout
+			if timeout == 0 {
+				timeout = time.Minute
+			}
+
+			start := time.Now()
+			err := provider.retry(context.Background(), timeout, func(ctx context.Context) error {
+				_, err := provider.apiClient.GetZone(ctx, zone.ID)
+
+				return err
+			})
+
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.requests, server.Requests()-requests)
+
+			if tc.fault.Kind == fake.FaultRateLimit && tc.wantErr == "" {
+				assert.GreaterOrEqual(t, time.Since(start), tc.fault.Reset)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // t.Setenv can't be used in parallel tests
+func TestConfigureRetry(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		data    hetznerDNSProviderModel
+		env     map[string]string
+		want    retryConfig
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			want: retryConfig{maxAttempts: defaultRetryMaxAttempts, minBackoff: defaultRetryMinBackoff, maxBackoff: defaultRetryMaxBackoff},
+		},
+		{
+			name: "retry block",
+			data: hetznerDNSProviderModel{Retry: &retryModel{
+				MaxAttempts: types.Int64Value(5),
+				MinBackoff:  types.StringValue("100ms"),
+				MaxBackoff:  types.StringValue("2s"),
+			}},
+			want: retryConfig{maxAttempts: 5, minBackoff: 100 * time.Millisecond, maxBackoff: 2 * time.Second},
+		},
+		{
+			name: "env variables",
+			env: map[string]string{
+				"HETZNER_DNS_RETRY_MAX_ATTEMPTS": "4",
+				"HETZNER_DNS_RETRY_MIN_BACKOFF":  "2s",
+				"HETZNER_DNS_RETRY_MAX_BACKOFF":  "1m",
+			},
+			want: retryConfig{maxAttempts: 4, minBackoff: 2 * time.Second, maxBackoff: time.Minute},
+		},
+		{
+			name: "deprecated max_retries",
+			data: hetznerDNSProviderModel{MaxRetries: types.Int64Value(1)},
+			want: retryConfig{maxAttempts: 1, minBackoff: defaultRetryMinBackoff, maxBackoff: defaultRetryMaxBackoff},
+		},
+		{
+			name: "max_attempts takes precedence over max_retries",
+			data: hetznerDNSProviderModel{
+				MaxRetries: types.Int64Value(1),
+				Retry:      &retryModel{MaxAttempts: types.Int64Value(2)},
+			},
+			want: retryConfig{maxAttempts: 2, minBackoff: defaultRetryMinBackoff, maxBackoff: defaultRetryMaxBackoff},
+		},
+		{
+			name:    "invalid duration",
+			data:    hetznerDNSProviderModel{Retry: &retryModel{MinBackoff: types.StringValue("1 second")}},
+			wantErr: "must be a duration",
+		},
+		{
+			name:    "invalid env variable",
+			env:     map[string]string{"HETZNER_DNS_RETRY_MAX_ATTEMPTS": "many"},
+			wantErr: "must be an integer",
+		},
+		{
+			name: "min_backoff greater than max_backoff",
+			data: hetznerDNSProviderModel{Retry: &retryModel{
+				MinBackoff: types.StringValue("1m"),
+				MaxBackoff: types.StringValue("1s"),
+			}},
+			wantErr: "Invalid Retry Backoff",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, name := range []string{
+				"HETZNER_DNS_MAX_RETRIES", "HETZNER_DNS_RETRY_MAX_ATTEMPTS", "HETZNER_DNS_RETRY_MIN_BACKOFF", "HETZNER_DNS_RETRY_MAX_BACKOFF",
+			} {
+				t.Setenv(name, tc.env[name])
+
+				if _, ok := tc.env[name]; !ok {
+					require.NoError(t, os.Unsetenv(name))
+				}
+			}
+
+			got, diags := configureRetry(tc.data)
+
+			if tc.wantErr != "" {
+				require.True(t, diags.HasError())
+				assert.Equal(t, tc.wantErr, diags.Errors()[0].Summary())
+
+				return
+			}
+
+			require.False(t, diags.HasError(), diags)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
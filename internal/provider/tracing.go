@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// tracerName is the name of the OpenTelemetry tracer of the resource operations.
+const tracerName = "github.com/germanbrew/terraform-provider-hetznerdns/internal/provider"
+
+// traceFlushTimeout is the time the spans of an operation may take to be exported when it ends.
+const traceFlushTimeout = 5 * time.Second
+
+var errUnsupportedOTLPProtocol = errors.New("only the OTLP protocol http/protobuf is supported")
+
+// newTracerProvider returns a tracer provider exporting spans with OTLP over HTTP, configured by the standard
+// OTEL_* env variables, or nil if no OTLP endpoint is configured. The trace context in the env variables
+// TRACEPARENT and TRACESTATE is returned as the parent of all spans, e.g. to add the spans to the trace of a
+// CI pipeline.
+func newTracerProvider(ctx context.Context, version string) (*sdktrace.TracerProvider, trace.SpanContext, error) {
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") || os.Getenv("OTEL_TRACES_EXPORTER") == "none" ||
+		(os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "") {
+		return nil, trace.SpanContext{}, nil
+	}
+
+	protocol := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL")
+	if protocol == "" {
+		protocol = os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+	}
+
+	if protocol != "" && protocol != "http/protobuf" {
+		return nil, trace.SpanContext{}, fmt.Errorf("%w, got %q", errUnsupportedOTLPProtocol, protocol)
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, trace.SpanContext{}, fmt.Errorf("creating OTLP exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName("terraform-provider-hetznerdns"), semconv.ServiceVersion(version)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, trace.SpanContext{}, fmt.Errorf("creating OpenTelemetry resource: %w", err)
+	}
+
+	parent := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{
+		"traceparent": os.Getenv("TRACEPARENT"),
+		"tracestate":  os.Getenv("TRACESTATE"),
+	}))
+
+	return sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res)), parent, nil
+}
+
+// setTracerProvider makes the provider and its API client create spans with the given tracer provider.
+func (p *providerClient) setTracerProvider(tracerProvider *sdktrace.TracerProvider, parent trace.SpanContext) {
+	p.tracerProvider = tracerProvider
+	p.traceParent = parent
+	p.apiClient.SetTracerProvider(tracerProvider)
+}
+
+// startSpan starts the span of a resource operation, which is the parent of the spans of its API requests.
+// Spans without a parent in the context are children of the trace context of the environment.
+// The log subsystem of the API requests is added to the context once for the whole operation.
+func (p *providerClient) startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	ctx = api.WithLogSubsystem(ctx)
+
+	if p.tracerProvider == nil {
+		return noop.Tracer{}.Start(ctx, name)
+	}
+
+	if !trace.SpanContextFromContext(ctx).IsValid() && p.traceParent.IsValid() {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, p.traceParent)
+	}
+
+	return p.tracerProvider.Tracer(tracerName).Start(ctx, name, trace.WithSpanKind(trace.SpanKindInternal))
+}
+
+// endSpan ends the span of a resource operation with the status of its diagnostics and exports the spans,
+// because Terraform may stop the provider process at any time after an operation.
+func (p *providerClient) endSpan(ctx context.Context, span trace.Span, diags *diag.Diagnostics) {
+	if diags.HasError() {
+		span.SetStatus(codes.Error, diags.Errors()[0].Summary())
+	}
+
+	span.End()
+
+	if p.tracerProvider == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), traceFlushTimeout)
+	defer cancel()
+
+	if err := p.tracerProvider.ForceFlush(ctx); err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("exporting spans: %s", err))
+	}
+}
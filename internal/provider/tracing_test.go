@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api/fake"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRecordResourceTracing(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	provider, server, zone := newFaultTestProvider(t, 1)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	t.Cleanup(func() { _ = tracerProvider.Shutdown(context.Background()) })
+
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+
+	provider.setTracerProvider(tracerProvider, parent)
+
+	r := &recordResource{provider: provider}
+	schema := testResourceSchema(t, r).Schema
+
+	// The second record is refused by the API.
+	for i := range 2 {
+		if i == 1 {
+			server.InjectFaults(fake.Fault{Kind: fake.FaultErrorResponse, Method: http.MethodPost, Status: http.StatusUnprocessableEntity})
+		}
+
+		plan := tfsdk.Plan{Schema: schema}
+		require.False(t, plan.Set(ctx, &recordResourceModel{
+			ID:       types.StringUnknown(),
+			ZoneID:   types.StringValue(zone.ID),
+			Name:     newRecordNameValue("www"),
+			FQDN:     types.StringUnknown(),
+			Type:     types.StringValue("A"),
+			Value:    types.StringValue("192.0.2.1"),
+			TTL:      types.Int64Null(),
+			Timeouts: nullTimeouts(),
+		}).HasError())
+
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+		r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+	}
+
+	var operations []tracetest.SpanStub
+
+	requests := make(map[trace.SpanID][]string)
+
+	for _, span := range exporter.GetSpans() {
+		assert.Equal(t, parent.TraceID(), span.SpanContext.TraceID())
+
+		if span.Name == "hetznerdns_record Create" {
+			operations = append(operations, span)
+
+			continue
+		}
+
+		requests[span.Parent.SpanID()] = append(requests[span.Parent.SpanID()], span.Name)
+	}
+
+	require.Len(t, operations, 2)
+
+	assert.Equal(t, parent.SpanID(), operations[0].Parent.SpanID())
+	assert.Equal(t, codes.Unset, operations[0].Status.Code)
+	assert.Contains(t, requests[operations[0].SpanContext.SpanID()], "POST /api/v1/records")
+
+	assert.Equal(t, codes.Error, operations[1].Status.Code)
+	assert.Equal(t, "API Error", operations[1].Status.Description)
+}
+
+//nolint:paralleltest // t.Setenv can't be used in parallel tests
+func TestNewTracerProvider(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		env        map[string]string
+		enabled    bool
+		wantParent bool
+		wantErr    bool
+	}{
+		{
+			name: "disabled without endpoint",
+		},
+		{
+			name: "endpoint",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318",
+			},
+			enabled: true,
+		},
+		{
+			name: "traces endpoint with parent",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://localhost:4318/v1/traces",
+				"TRACEPARENT":                        "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+			},
+			enabled:    true,
+			wantParent: true,
+		},
+		{
+			name: "SDK disabled",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318",
+				"OTEL_SDK_DISABLED":           "true",
+			},
+		},
+		{
+			name: "exporter disabled",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318",
+				"OTEL_TRACES_EXPORTER":        "none",
+			},
+		},
+		{
+			name: "unsupported protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4317",
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc",
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, name := range []string{
+				"OTEL_SDK_DISABLED", "OTEL_TRACES_EXPORTER", "OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT",
+				"OTEL_EXPORTER_OTLP_PROTOCOL", "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL", "TRACEPARENT", "TRACESTATE",
+			} {
+				t.Setenv(name, tc.env[name])
+
+				if _, ok := tc.env[name]; !ok {
+					require.NoError(t, os.Unsetenv(name))
+				}
+			}
+
+			tracerProvider, parent, err := newTracerProvider(context.Background(), "test")
+			if tc.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.enabled, tracerProvider != nil)
+			assert.Equal(t, tc.wantParent, parent.IsValid())
+
+			if tracerProvider != nil {
+				require.NoError(t, tracerProvider.Shutdown(context.Background()))
+			}
+		})
+	}
+}
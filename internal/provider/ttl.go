@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/policy"
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ttlConfig holds the TTL settings of the provider. Zero values are not set.
+type ttlConfig struct {
+	defaultRecordTTL int64
+	minTTL           int64
+	maxTTL           int64
+}
+
+func configureTTL(data hetznerDNSProviderModel) (ttlConfig, diag.Diagnostics) {
+	var (
+		config ttlConfig
+		diags  diag.Diagnostics
+		err    error
+	)
+
+	for _, setting := range []struct {
+		name   string
+		attr   types.Int64
+		envVar string
+		value  *int64
+	}{
+		{name: "default_record_ttl", attr: data.DefaultRecordTTL, envVar: "HETZNER_DNS_DEFAULT_RECORD_TTL", value: &config.defaultRecordTTL},
+		{name: "min_ttl", attr: data.MinTTL, envVar: "HETZNER_DNS_MIN_TTL", value: &config.minTTL},
+		{name: "max_ttl", attr: data.MaxTTL, envVar: "HETZNER_DNS_MAX_TTL", value: &config.maxTTL},
+	} {
+		*setting.value, err = utils.ConfigureInt64Attribute(setting.attr, setting.envVar, 0)
+		if err != nil {
+			diags.AddAttributeError(path.Root(setting.name), "must be an integer", err.Error())
+		} else if *setting.value < 0 {
+			diags.AddAttributeError(path.Root(setting.name), "must not be negative",
+				fmt.Sprintf("The TTL must not be negative, got: %d", *setting.value))
+		}
+	}
+
+	if diags.HasError() {
+		return config, diags
+	}
+
+	if config.maxTTL > 0 && config.minTTL > config.maxTTL {
+		diags.AddAttributeError(path.Root("min_ttl"), "Invalid TTL Bounds",
+			fmt.Sprintf("min_ttl (%d) must not be greater than max_ttl (%d)", config.minTTL, config.maxTTL))
+
+		return config, diags
+	}
+
+	if config.defaultRecordTTL > 0 {
+		if err := (policy.Policy{MinTTL: config.minTTL, MaxTTL: config.maxTTL}).CheckTTL(config.defaultRecordTTL); err != nil {
+			diags.AddAttributeError(path.Root("default_record_ttl"), "TTL Out of Bounds", "The default_record_ttl violates the bounds: "+err.Error())
+		}
+	}
+
+	return config, diags
+}
@@ -0,0 +1,306 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/policy"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // t.Setenv can't be used in parallel tests
+func TestConfigureTTL(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		data    hetznerDNSProviderModel
+		env     map[string]string
+		want    ttlConfig
+		wantErr string
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name: "attributes",
+			data: hetznerDNSProviderModel{
+				DefaultRecordTTL: types.Int64Value(300),
+				MinTTL:           types.Int64Value(60),
+				MaxTTL:           types.Int64Value(86400),
+			},
+			want: ttlConfig{defaultRecordTTL: 300, minTTL: 60, maxTTL: 86400},
+		},
+		{
+			name: "env variables",
+			env: map[string]string{
+				"HETZNER_DNS_DEFAULT_RECORD_TTL": "600",
+				"HETZNER_DNS_MIN_TTL":            "300",
+				"HETZNER_DNS_MAX_TTL":            "3600",
+			},
+			want: ttlConfig{defaultRecordTTL: 600, minTTL: 300, maxTTL: 3600},
+		},
+		{
+			name:    "invalid env variable",
+			env:     map[string]string{"HETZNER_DNS_MIN_TTL": "short"},
+			wantErr: "must be an integer",
+		},
+		{
+			name:    "negative env variable",
+			env:     map[string]string{"HETZNER_DNS_MAX_TTL": "-1"},
+			wantErr: "must not be negative",
+		},
+		{
+			name:    "min_ttl greater than max_ttl",
+			data:    hetznerDNSProviderModel{MinTTL: types.Int64Value(3600), MaxTTL: types.Int64Value(60)},
+			wantErr: "Invalid TTL Bounds",
+		},
+		{
+			name:    "default_record_ttl out of bounds",
+			data:    hetznerDNSProviderModel{DefaultRecordTTL: types.Int64Value(30), MinTTL: types.Int64Value(60)},
+			wantErr: "TTL Out of Bounds",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, name := range []string{"HETZNER_DNS_DEFAULT_RECORD_TTL", "HETZNER_DNS_MIN_TTL", "HETZNER_DNS_MAX_TTL"} {
+				t.Setenv(name, tc.env[name])
+
+				if _, ok := tc.env[name]; !ok {
+					require.NoError(t, os.Unsetenv(name))
+				}
+			}
+
+			got, diags := configureTTL(tc.data)
+
+			if tc.wantErr != "" {
+				require.True(t, diags.HasError())
+				assert.Equal(t, tc.wantErr, diags.Errors()[0].Summary())
+
+				return
+			}
+
+			require.False(t, diags.HasError(), diags)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRecordResourceModifyPlanTTL(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		ttl     ttlConfig
+		policy  policy.Policy
+		planTTL types.Int64
+		zoneID  string
+		want    types.Int64
+		wantErr bool
+	}{
+		{
+			name:    "ttl",
+			planTTL: types.Int64Value(300),
+			want:    types.Int64Value(300),
+		},
+		{
+			name:    "zone ttl",
+			planTTL: types.Int64Null(),
+			want:    types.Int64Value(3600),
+		},
+		{
+			name:    "default record ttl",
+			ttl:     ttlConfig{defaultRecordTTL: 600},
+			planTTL: types.Int64Null(),
+			want:    types.Int64Value(600),
+		},
+		{
+			name:    "unknown ttl",
+			planTTL: types.Int64Unknown(),
+			want:    types.Int64Unknown(),
+		},
+		{
+			name:    "unknown zone",
+			planTTL: types.Int64Null(),
+			zoneID:  "unknown",
+			want:    types.Int64Unknown(),
+		},
+		{
+			name:    "within bounds",
+			policy:  policy.Policy{MinTTL: 60, MaxTTL: 3600},
+			planTTL: types.Int64Value(3600),
+			want:    types.Int64Value(3600),
+		},
+		{
+			name:    "less than min_ttl",
+			policy:  policy.Policy{MinTTL: 60},
+			planTTL: types.Int64Value(30),
+			wantErr: true,
+		},
+		{
+			name:    "greater than max_ttl",
+			policy:  policy.Policy{MaxTTL: 3600},
+			planTTL: types.Int64Value(86400),
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			provider, _, zone := newFaultTestProvider(t, 1)
+			provider.ttl = tc.ttl
+			provider.policy = tc.policy
+			r := &recordResource{provider: provider}
+			schema := testResourceSchema(t, r).Schema
+
+			zoneID := types.StringValue(zone.ID)
+			if tc.zoneID == "unknown" {
+				zoneID = types.StringUnknown()
+			}
+
+			plan := tfsdk.Plan{Schema: schema}
+			require.False(t, plan.Set(ctx, &recordResourceModel{
+				ID:           types.StringUnknown(),
+				ZoneID:       zoneID,
+				Name:         newRecordNameValue("www"),
+				FQDN:         types.StringUnknown(),
+				Type:         types.StringValue("A"),
+				Value:        types.StringValue("192.0.2.1"),
+				TTL:          tc.planTTL,
+				EffectiveTTL: types.Int64Unknown(),
+				Timeouts:     nullTimeouts(),
+			}).HasError())
+
+			state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: schema, Raw: plan.Raw}, State: state, Plan: plan}, &resp)
+
+			if tc.wantErr {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, "Policy Violation", resp.Diagnostics.Errors()[0].Summary())
+
+				return
+			}
+
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			var effectiveTTL types.Int64
+
+			require.False(t, resp.Plan.GetAttribute(ctx, path.Root("effective_ttl"), &effectiveTTL).HasError())
+			assert.Equal(t, tc.want, effectiveTTL)
+		})
+	}
+}
+
+func TestRecordResourceDefaultRecordTTL(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	provider, _, zone := newFaultTestProvider(t, 1)
+	provider.ttl = ttlConfig{defaultRecordTTL: 600}
+	r := &recordResource{provider: provider}
+	schema := testResourceSchema(t, r).Schema
+
+	plan := tfsdk.Plan{Schema: schema}
+	require.False(t, plan.Set(ctx, &recordResourceModel{
+		ID:           types.StringUnknown(),
+		ZoneID:       types.StringValue(zone.ID),
+		Name:         newRecordNameValue("www"),
+		FQDN:         types.StringUnknown(),
+		Type:         types.StringValue("A"),
+		Value:        types.StringValue("192.0.2.1"),
+		TTL:          types.Int64Null(),
+		EffectiveTTL: types.Int64Unknown(),
+		Timeouts:     nullTimeouts(),
+	}).HasError())
+
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+
+	var state recordResourceModel
+
+	require.False(t, createResp.State.Get(ctx, &state).HasError())
+	assert.True(t, state.TTL.IsNull())
+	assert.Equal(t, types.Int64Value(600), state.EffectiveTTL)
+
+	record, err := provider.apiClient.GetRecord(ctx, state.ID.ValueString())
+	require.NoError(t, err)
+	require.NotNil(t, record.TTL)
+	assert.Equal(t, int64(600), *record.TTL)
+
+	// The TTL set from the default record TTL isn't read back into ttl.
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+
+	require.False(t, readResp.State.Get(ctx, &state).HasError())
+	assert.True(t, state.TTL.IsNull())
+	assert.Equal(t, types.Int64Value(600), state.EffectiveTTL)
+}
+
+func TestZoneResourceModifyPlanTTL(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		ttl     types.Int64
+		wantErr bool
+	}{
+		{
+			name: "within bounds",
+			ttl:  types.Int64Value(3600),
+		},
+		{
+			name: "null",
+			ttl:  types.Int64Null(),
+		},
+		{
+			name:    "less than min_ttl",
+			ttl:     types.Int64Value(60),
+			wantErr: true,
+		},
+		{
+			name:    "greater than max_ttl",
+			ttl:     types.Int64Value(86400),
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			r := &zoneResource{provider: &providerClient{policy: policy.Policy{MinTTL: 300, MaxTTL: 3600}}}
+			schema := testResourceSchema(t, r).Schema
+
+			plan := tfsdk.Plan{Schema: schema}
+			require.False(t, plan.Set(ctx, &zoneResourceModel{
+				ID:                       types.StringUnknown(),
+				Name:                     types.StringValue("example.com"),
+				TTL:                      tc.ttl,
+				NS:                       types.ListUnknown(types.StringType),
+				AdoptExisting:            types.BoolValue(false),
+				DeleteProtection:         types.BoolValue(false),
+				PreventDeleteWithRecords: types.BoolValue(false),
+				Timeouts:                 nullTimeouts(),
+			}).HasError())
+
+			state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: schema, Raw: plan.Raw}, State: state, Plan: plan}, &resp)
+
+			require.Equal(t, tc.wantErr, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			if tc.wantErr {
+				assert.Equal(t, "Policy Violation", resp.Diagnostics.Errors()[0].Summary())
+			}
+		})
+	}
+}
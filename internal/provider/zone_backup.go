@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api"
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Operations which trigger a zone backup.
+const (
+	backupDeleteZone   = "delete-zone"
+	backupDeleteRecord = "delete-record"
+	backupUpdateRecord = "update-record"
+)
+
+// zoneBackup writes the records of zones to BIND zone files before they are changed. Zones are backed
+// up before they are deleted and before the first change of their records by the provider process, so
+// an apply changing many records of a zone writes a single snapshot of the zone as it was before.
+type zoneBackup struct {
+	dir string
+	now func() time.Time
+
+	mu       sync.Mutex
+	recorded map[string]*recordedBackup
+}
+
+// recordedBackup is the backup of a zone before the first change of its records. done is closed once the
+// backup has been written or has failed.
+type recordedBackup struct {
+	done   chan struct{}
+	failed bool
+}
+
+// newZoneBackup returns a zoneBackup writing to dir, or nil if dir is empty which disables backups.
+func newZoneBackup(dir string) (*zoneBackup, error) {
+	if dir == "" {
+		return nil, nil
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("creating backup directory: %w", err)
+	}
+
+	return &zoneBackup{dir: dir, now: time.Now, recorded: make(map[string]*recordedBackup)}, nil
+}
+
+// backupZone writes the current records of the zone to the backup directory before the given operation.
+// Record operations back up a zone only once per provider process. Operations on the records of a zone wait
+// for the backup of the zone which is in progress, while operations on other zones aren't blocked by it.
+func (p *providerClient) backupZone(ctx context.Context, timeout time.Duration, zoneID, operation string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	b := p.backup
+	if b == nil {
+		return diags
+	}
+
+	if operation == backupDeleteZone {
+		return p.writeZoneBackup(ctx, timeout, zoneID, operation)
+	}
+
+	for {
+		b.mu.Lock()
+		recorded, ok := b.recorded[zoneID]
+
+		if !ok {
+			recorded = &recordedBackup{done: make(chan struct{})}
+			b.recorded[zoneID] = recorded
+		}
+
+		b.mu.Unlock()
+
+		if !ok {
+			diags = p.writeZoneBackup(ctx, timeout, zoneID, operation)
+
+			if diags.HasError() {
+				// The next operation on the records of the zone tries again.
+				b.mu.Lock()
+				delete(b.recorded, zoneID)
+				b.mu.Unlock()
+
+				recorded.failed = true
+			}
+
+			close(recorded.done)
+
+			return diags
+		}
+
+		select {
+		case <-recorded.done:
+		case <-ctx.Done():
+			diags.AddError("Backup Error", fmt.Sprintf("waiting for the backup of zone %s before %s: %s", zoneID, operation, ctx.Err()))
+
+			return diags
+		}
+
+		if !recorded.failed {
+			return diags
+		}
+	}
+}
+
+// writeZoneBackup reads the zone and its records and writes them to a new backup file.
+func (p *providerClient) writeZoneBackup(ctx context.Context, timeout time.Duration, zoneID, operation string) diag.Diagnostics {
+	var (
+		diags   diag.Diagnostics
+		zone    *api.Zone
+		records *[]api.Record
+	)
+
+	err := p.retry(ctx, timeout, func(ctx context.Context) error {
+		var err error
+
+		zone, err = p.apiClient.GetZone(ctx, zoneID)
+		if err != nil {
+			return err
+		}
+
+		records, err = p.apiClient.GetRecordsByZoneID(ctx, zoneID)
+
+		return err
+	})
+	if err != nil {
+		diags.AddError("Backup Error", fmt.Sprintf("reading zone %s for the backup before %s: %s", zoneID, operation, err))
+
+		return diags
+	}
+
+	filename, err := p.backup.write(zone, *records, operation)
+	if err != nil {
+		diags.AddError("Backup Error", fmt.Sprintf("writing the backup of zone %q before %s: %s", zone.Name, operation, err))
+
+		return diags
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("backed up zone %s to %s", zone.Name, filename))
+
+	return diags
+}
+
+// write writes the zone file named after the zone, the current time and the operation, and returns its path.
+func (b *zoneBackup) write(zone *api.Zone, records []api.Record, operation string) (string, error) {
+	now := b.now().UTC()
+
+	var buf bytes.Buffer
+
+	_, _ = fmt.Fprintf(&buf, "; Backup of zone %s (%s) before %s at %s\n", zone.Name, zone.ID, operation, now.Format(time.RFC3339))
+	writeBINDZone(&buf, zone, records)
+
+	filename := filepath.Join(b.dir, fmt.Sprintf("%s_%s_%s.zone", zone.Name, now.Format("20060102T150405.000Z"), operation))
+
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return "", fmt.Errorf("creating backup file: %w", err)
+	}
+
+	if _, err = buf.WriteTo(file); err != nil {
+		_ = file.Close()
+
+		return "", fmt.Errorf("writing backup file: %w", err)
+	}
+
+	if err = file.Close(); err != nil {
+		return "", fmt.Errorf("closing backup file: %w", err)
+	}
+
+	return filename, nil
+}
+
+// writeBINDZone writes the records in the BIND zone file format. Record names are relative to the zone
+// origin and records without a TTL use the TTL of the zone. TXT values which aren't made of character
+// strings, e.g. values created without the TXT formatter, are quoted so the file can be loaded.
+func writeBINDZone(w io.Writer, zone *api.Zone, records []api.Record) {
+	_, _ = fmt.Fprintf(w, "$ORIGIN %s.\n", strings.TrimSuffix(zone.Name, "."))
+	_, _ = fmt.Fprintf(w, "$TTL %d\n", zone.TTL)
+
+	for _, record := range records {
+		ttl := ""
+		if record.HasTTL() {
+			ttl = strconv.FormatInt(*record.TTL, 10)
+		}
+
+		value := record.Value
+		if record.Type == "TXT" && !utils.IsTXTCharacterStrings(value) {
+			value = utils.EncodeTXTRecordValue(value)
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\tIN\t%s\t%s\n", record.Name, ttl, record.Type, value)
+	}
+}
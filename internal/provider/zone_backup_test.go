@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api"
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteBINDZone(t *testing.T) {
+	t.Parallel()
+
+	ttl := int64(60)
+	zone := &api.Zone{ID: "1", Name: "example.com", TTL: 3600}
+	records := []api.Record{
+		{Name: "@", Type: "NS", Value: "hydrogen.ns.hetzner.com."},
+		{Name: "www", Type: "A", Value: "192.0.2.1", TTL: &ttl},
+		{Name: "@", Type: "TXT", Value: `"v=spf1 -all"`},
+		{Name: "legacy", Type: "TXT", Value: `say "hello"`},
+	}
+
+	var b strings.Builder
+
+	writeBINDZone(&b, zone, records)
+
+	assert.Equal(t, "$ORIGIN example.com.\n"+
+		"$TTL 3600\n"+
+		"@\t\tIN\tNS\thydrogen.ns.hetzner.com.\n"+
+		"www\t60\tIN\tA\t192.0.2.1\n"+
+		"@\t\tIN\tTXT\t\"v=spf1 -all\"\n"+
+		"legacy\t\tIN\tTXT\t\"say \\\"hello\\\"\"\n", b.String())
+}
+
+func TestProviderClientBackupZone(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	provider, _, zone := newFaultTestProvider(t, 1)
+
+	_, err := provider.apiClient.CreateRecord(ctx, api.CreateRecordOpts{ZoneID: zone.ID, Type: "A", Name: "www", Value: "192.0.2.1"})
+	require.NoError(t, err)
+
+	// Backups are disabled without a backup directory.
+	require.False(t, provider.backupZone(ctx, time.Minute, zone.ID, backupDeleteZone).HasError())
+
+	dir := filepath.Join(t.TempDir(), "backups")
+
+	provider.backup, err = newZoneBackup(dir)
+	require.NoError(t, err)
+
+	now := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	provider.backup.now = func() time.Time {
+		now = now.Add(time.Second)
+
+		return now
+	}
+
+	// Record changes back up a zone only once.
+	require.False(t, provider.backupZone(ctx, time.Minute, zone.ID, backupDeleteRecord).HasError())
+	require.False(t, provider.backupZone(ctx, time.Minute, zone.ID, backupUpdateRecord).HasError())
+	require.False(t, provider.backupZone(ctx, time.Minute, zone.ID, backupDeleteZone).HasError())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	assert.Equal(t, []string{
+		"example.com_20240501T123001.000Z_delete-record.zone",
+		"example.com_20240501T123002.000Z_delete-zone.zone",
+	}, names)
+
+	data, err := os.ReadFile(filepath.Join(dir, names[0]))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "; Backup of zone example.com ("+zone.ID+") before delete-record at 2024-05-01T12:30:01Z\n")
+	assert.Contains(t, string(data), "$ORIGIN example.com.\n$TTL 3600\n")
+	assert.Contains(t, string(data), "www\t\tIN\tA\t192.0.2.1\n")
+
+	diags := provider.backupZone(ctx, time.Minute, "unknown", backupDeleteZone)
+	require.True(t, diags.HasError())
+	assert.Equal(t, "Backup Error", diags.Errors()[0].Summary())
+}
+
+func TestProviderClientBackupZoneConcurrent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	provider, server, slowZone := newFaultTestProvider(t, 1)
+
+	zone, err := provider.apiClient.CreateZone(ctx, api.CreateZoneOpts{Name: "example.org", TTL: 3600})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+
+	provider.backup, err = newZoneBackup(dir)
+	require.NoError(t, err)
+
+	delay := 2 * time.Second
+	server.InjectFaults(fake.Fault{Kind: fake.FaultSlowResponse, Path: "/api/v1/zones/" + slowZone.ID, Delay: delay})
+
+	results := make(chan bool, 2)
+
+	for range 2 {
+		go func() {
+			results <- provider.backupZone(ctx, time.Minute, slowZone.ID, backupDeleteRecord).HasError()
+		}()
+	}
+
+	require.Eventually(t, func() bool { return server.PendingFaults() == 0 }, time.Second, time.Millisecond)
+
+	// The slow backup of one zone doesn't block the backup of another zone.
+	start := time.Now()
+
+	require.False(t, provider.backupZone(ctx, time.Minute, zone.ID, backupUpdateRecord).HasError())
+	assert.Less(t, time.Since(start), delay/2)
+
+	// Concurrent operations on the records of the slow zone wait for its single backup.
+	assert.False(t, <-results)
+	assert.False(t, <-results)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.True(t, strings.HasPrefix(entries[0].Name(), "example.com_"), entries[0].Name())
+	assert.True(t, strings.HasPrefix(entries[1].Name(), "example.org_"), entries[1].Name())
+
+	// A failed backup is tried again by the next operation.
+	require.True(t, provider.backupZone(ctx, time.Minute, "unknown", backupDeleteRecord).HasError())
+	require.True(t, provider.backupZone(ctx, time.Minute, "unknown", backupDeleteRecord).HasError())
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"sync"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api"
+)
+
+// zoneCache caches zones and their records for the lifetime of the provider process.
+// During planning every record resource of a zone needs the zone and the full list of
+// records in that zone, so without the cache a plan would fetch them once per record.
+type zoneCache struct {
+	mu      sync.Mutex
+	zones   map[string]*api.Zone
+	zoneIDs map[string]string
+	records map[string][]api.Record
+}
+
+func newZoneCache() *zoneCache {
+	return &zoneCache{
+		zones:   make(map[string]*api.Zone),
+		zoneIDs: make(map[string]string),
+		records: make(map[string][]api.Record),
+	}
+}
+
+// Zone returns the zone with the given ID, fetching it from the API on first use.
+func (c *zoneCache) Zone(ctx context.Context, client *api.Client, zoneID string) (*api.Zone, error) {
+	if c == nil {
+		return client.GetZone(ctx, zoneID)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if zone, ok := c.zones[zoneID]; ok {
+		return zone, nil
+	}
+
+	zone, err := client.GetZone(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.zones[zoneID] = zone
+
+	return zone, nil
+}
+
+// ZoneByName returns the zone with the given name, looking it up by its name on first use.
+// Zones which don't exist are not cached, because they may be created later by the same apply.
+func (c *zoneCache) ZoneByName(ctx context.Context, client *api.Client, name string) (*api.Zone, error) {
+	if c == nil {
+		return client.GetZoneByName(ctx, name)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if zone, ok := c.zones[c.zoneIDs[name]]; ok {
+		return zone, nil
+	}
+
+	zone, err := client.GetZoneByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	c.zones[zone.ID] = zone
+	c.zoneIDs[name] = zone.ID
+
+	return zone, nil
+}
+
+// Records returns the records of the given zone, fetching them from the API on first use.
+func (c *zoneCache) Records(ctx context.Context, client *api.Client, zoneID string) ([]api.Record, error) {
+	if c == nil {
+		records, err := client.GetRecordsByZoneID(ctx, zoneID)
+		if err != nil {
+			return nil, err
+		}
+
+		return *records, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if records, ok := c.records[zoneID]; ok {
+		return records, nil
+	}
+
+	records, err := client.GetRecordsByZoneID(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.records[zoneID] = *records
+
+	return *records, nil
+}
+
+// Invalidate drops the cached zone and records of the given zone. It must be called after
+// every change to a zone or its records.
+func (c *zoneCache) Invalidate(zoneID string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.zones, zoneID)
+	delete(c.records, zoneID)
+}
@@ -12,7 +12,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -117,24 +116,14 @@ func (d *zoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	}
 
 	var (
-		err     error
-		zone    *api.Zone
-		retries int64
+		err  error
+		zone *api.Zone
 	)
 
-	err = retry.RetryContext(ctx, readTimeout, func() *retry.RetryError {
-		retries++
-
+	err = d.provider.retry(ctx, readTimeout, func(ctx context.Context) error {
 		zone, err = d.provider.apiClient.GetZoneByName(ctx, data.Name.ValueString())
-		if err != nil {
-			if retries == d.provider.maxRetries {
-				return retry.NonRetryableError(err)
-			}
-
-			return retry.RetryableError(err)
-		}
 
-		return nil
+		return err
 	})
 	if err != nil {
 		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to get zone, got error: %s", err))
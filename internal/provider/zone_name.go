@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// zoneNameDescription is the description of the zone_name attribute of the resources in a zone.
+const zoneNameDescription = "Name of the DNS zone, e.g. `example.com`, as an alternative to `zone_id`. The zone is looked up by its name " +
+	"and its ID is stored in `zone_id`. A change of the ID, e.g. because the zone was recreated, replaces the resource. " +
+	"Conflicts with `zone_id`."
+
+// lookupZoneID returns the ID of the zone with the given name. Zone names are compared without a trailing dot and case.
+func (p *providerClient) lookupZoneID(ctx context.Context, name string) (string, error) {
+	zone, err := p.cache.ZoneByName(ctx, p.apiClient, strings.ToLower(strings.TrimSuffix(name, ".")))
+	if err != nil {
+		return "", fmt.Errorf("looking up zone %s: %w", name, err)
+	}
+
+	return zone.ID, nil
+}
+
+// resolveZoneID returns the ID of the zone of a resource, which is zone_id if it is known and
+// otherwise the ID of the zone named zone_name.
+func (p *providerClient) resolveZoneID(ctx context.Context, zoneID, zoneName types.String) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !zoneID.IsUnknown() {
+		return zoneID, diags
+	}
+
+	id, err := p.lookupZoneID(ctx, zoneName.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("zone_name"), "API Error", err.Error())
+
+		return zoneID, diags
+	}
+
+	return types.StringValue(id), diags
+}
+
+// stateZoneID returns the zone_id of the state if the resource references the same zone_name as in the state,
+// which plans the zone_id without looking up the zone. Otherwise the zone_id is unknown.
+func (p *providerClient) stateZoneID(ctx context.Context, req resource.ModifyPlanRequest, zoneName types.String) types.String {
+	var stateZoneID, stateZoneName types.String
+
+	if req.State.Raw.IsNull() ||
+		req.State.GetAttribute(ctx, path.Root("zone_id"), &stateZoneID).HasError() ||
+		req.State.GetAttribute(ctx, path.Root("zone_name"), &stateZoneName).HasError() ||
+		!strings.EqualFold(strings.TrimSuffix(stateZoneName.ValueString(), "."), strings.TrimSuffix(zoneName.ValueString(), ".")) {
+		return types.StringUnknown()
+	}
+
+	return stateZoneID
+}
+
+// planZoneID plans the zone_id of a resource referencing its zone by zone_name and returns the planned zone_id.
+// The ID is unknown if the zone doesn't exist yet, e.g. because it is created by the same apply. The resource
+// is replaced if the ID differs from the one in the state. If the provider skips plan lookups, the ID is only
+// known if the zone_name is unchanged.
+func (p *providerClient) planZoneID(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) (types.String, diag.Diagnostics) {
+	var (
+		diags            diag.Diagnostics
+		zoneID, zoneName types.String
+		stateZoneID      types.String
+	)
+
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("zone_id"), &zoneID)...)
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("zone_name"), &zoneName)...)
+
+	if diags.HasError() || zoneName.IsNull() {
+		return zoneID, diags
+	}
+
+	zoneID = types.StringUnknown()
+
+	switch {
+	case zoneName.IsUnknown():
+		// The zone_id stays unknown.
+	case p.skipPlanLookups:
+		zoneID = p.stateZoneID(ctx, req, zoneName)
+	default:
+		id, err := p.lookupZoneID(ctx, zoneName.ValueString())
+
+		switch {
+		case errors.Is(err, api.ErrNotFound):
+			// The zone is looked up again when the resource is created.
+		case err != nil:
+			diags.AddAttributeError(path.Root("zone_name"), "API Error", err.Error())
+
+			return zoneID, diags
+		default:
+			zoneID = types.StringValue(id)
+		}
+	}
+
+	diags.Append(resp.Plan.SetAttribute(ctx, path.Root("zone_id"), zoneID)...)
+
+	if req.State.Raw.IsNull() {
+		return zoneID, diags
+	}
+
+	diags.Append(req.State.GetAttribute(ctx, path.Root("zone_id"), &stateZoneID)...)
+
+	if !zoneID.Equal(stateZoneID) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("zone_id"))
+	}
+
+	return zoneID, diags
+}
@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrimaryServerResourceModifyPlanZoneName(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name          string
+		zoneName      types.String
+		existing      bool
+		recreated     bool
+		wantUnknown   bool
+		wantReplace   bool
+		configZoneID  bool
+		wantNoRequest bool
+	}{
+		{
+			name:     "create",
+			zoneName: types.StringValue("example.com"),
+		},
+		{
+			name:     "trailing dot and upper case",
+			zoneName: types.StringValue("Example.COM."),
+		},
+		{
+			name:        "zone created by the same apply",
+			zoneName:    types.StringValue("example.org"),
+			wantUnknown: true,
+		},
+		{
+			name:        "unknown zone name",
+			zoneName:    types.StringUnknown(),
+			wantUnknown: true,
+		},
+		{
+			name:     "unchanged zone",
+			zoneName: types.StringValue("example.com"),
+			existing: true,
+		},
+		{
+			name:        "recreated zone",
+			zoneName:    types.StringValue("example.com"),
+			existing:    true,
+			recreated:   true,
+			wantReplace: true,
+		},
+		{
+			name:          "zone_id",
+			zoneName:      types.StringNull(),
+			configZoneID:  true,
+			wantNoRequest: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			provider, server, zone := newFaultTestProvider(t, 1)
+			r := &primaryServerResource{provider: provider}
+			schema := testResourceSchema(t, r).Schema
+
+			model := primaryServerResourceModel{
+				ID:       types.StringUnknown(),
+				Address:  types.StringValue("192.0.2.1"),
+				Port:     types.Int64Value(53),
+				ZoneID:   types.StringUnknown(),
+				ZoneName: tc.zoneName,
+				Timeouts: nullTimeouts(),
+			}
+
+			if tc.configZoneID {
+				model.ZoneID = types.StringValue(zone.ID)
+			}
+
+			state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+			if tc.existing {
+				stateModel := model
+				stateModel.ID = types.StringValue("1")
+				stateModel.ZoneID = types.StringValue(zone.ID)
+
+				if tc.recreated {
+					stateModel.ZoneID = types.StringValue("deleted")
+				}
+
+				require.False(t, state.Set(ctx, &stateModel).HasError())
+			}
+
+			plan := tfsdk.Plan{Schema: schema}
+			require.False(t, plan.Set(ctx, &model).HasError())
+
+			requests := server.Requests()
+
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: schema, Raw: plan.Raw}, State: state, Plan: plan}, &resp)
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			var zoneID types.String
+
+			require.False(t, resp.Plan.GetAttribute(ctx, path.Root("zone_id"), &zoneID).HasError())
+
+			if tc.wantUnknown {
+				assert.True(t, zoneID.IsUnknown())
+			} else {
+				assert.Equal(t, zone.ID, zoneID.ValueString())
+			}
+
+			assert.Equal(t, tc.wantReplace, len(resp.RequiresReplace) > 0, resp.RequiresReplace)
+
+			if tc.wantNoRequest {
+				assert.Equal(t, requests, server.Requests())
+			}
+		})
+	}
+}
+
+func TestZoneCacheZoneByName(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	provider, server, zone := newFaultTestProvider(t, 1)
+
+	requests := server.Requests()
+
+	for range 2 {
+		zoneID, err := provider.lookupZoneID(ctx, "example.com")
+		require.NoError(t, err)
+		assert.Equal(t, zone.ID, zoneID)
+	}
+
+	assert.Equal(t, requests+1, server.Requests())
+
+	provider.cache.Invalidate(zone.ID)
+
+	_, err := provider.lookupZoneID(ctx, "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, requests+2, server.Requests())
+}
+
+func TestRecordResourceCreateZoneName(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	provider, _, zone := newFaultTestProvider(t, 1)
+	r := &recordResource{provider: provider}
+	schema := testResourceSchema(t, r).Schema
+
+	plan := tfsdk.Plan{Schema: schema}
+	require.False(t, plan.Set(ctx, &recordResourceModel{
+		ID:           types.StringUnknown(),
+		ZoneID:       types.StringUnknown(),
+		ZoneName:     types.StringValue("example.com"),
+		Name:         newRecordNameValue("www"),
+		FQDN:         types.StringUnknown(),
+		Type:         types.StringValue("A"),
+		Value:        types.StringValue("192.0.2.1"),
+		TTL:          types.Int64Null(),
+		EffectiveTTL: types.Int64Unknown(),
+		Timeouts:     nullTimeouts(),
+	}).HasError())
+
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var state recordResourceModel
+
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	assert.Equal(t, zone.ID, state.ZoneID.ValueString())
+	assert.Equal(t, "example.com", state.ZoneName.ValueString())
+	assert.Equal(t, "www.example.com.", state.FQDN.ValueString())
+	assert.Equal(t, types.Int64Value(zone.TTL), state.EffectiveTTL)
+}
+
+func TestRecordResourceModifyPlanSkipPlanLookups(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name             string
+		zoneName         types.String
+		existing         bool
+		wantZoneID       bool
+		wantEffectiveTTL bool
+	}{
+		{
+			name:     "create with zone_name",
+			zoneName: types.StringValue("example.com"),
+		},
+		{
+			name:       "create with zone_id",
+			zoneName:   types.StringNull(),
+			wantZoneID: true,
+		},
+		{
+			name:             "unchanged zone_name",
+			zoneName:         types.StringValue("example.com"),
+			existing:         true,
+			wantZoneID:       true,
+			wantEffectiveTTL: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			provider, server, zone := newFaultTestProvider(t, 1)
+			provider.skipPlanLookups = true
+			r := &recordResource{provider: provider}
+			schema := testResourceSchema(t, r).Schema
+
+			model := recordResourceModel{
+				ID:                   types.StringUnknown(),
+				ZoneID:               types.StringUnknown(),
+				ZoneName:             tc.zoneName,
+				Name:                 newRecordNameValue("www"),
+				FQDN:                 types.StringUnknown(),
+				Type:                 types.StringValue("A"),
+				Value:                types.StringValue("192.0.2.1"),
+				TTL:                  types.Int64Null(),
+				EffectiveTTL:         types.Int64Unknown(),
+				AllowProtectedChange: types.BoolValue(false),
+				Timeouts:             nullTimeouts(),
+			}
+
+			if tc.zoneName.IsNull() {
+				model.ZoneID = types.StringValue(zone.ID)
+			}
+
+			state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+			if tc.existing {
+				model.ID = types.StringValue("1")
+				model.ZoneID = types.StringValue(zone.ID)
+				model.FQDN = types.StringValue("www.example.com.")
+				model.EffectiveTTL = types.Int64Value(zone.TTL)
+
+				require.False(t, state.Set(ctx, &model).HasError())
+			}
+
+			plan := tfsdk.Plan{Schema: schema}
+			require.False(t, plan.Set(ctx, &model).HasError())
+
+			requests := server.Requests()
+
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: schema, Raw: plan.Raw}, State: state, Plan: plan}, &resp)
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			var planned recordResourceModel
+
+			require.False(t, resp.Plan.Get(ctx, &planned).HasError())
+			assert.Equal(t, tc.wantZoneID, !planned.ZoneID.IsUnknown(), planned.ZoneID)
+			assert.Equal(t, tc.wantEffectiveTTL, !planned.EffectiveTTL.IsUnknown(), planned.EffectiveTTL)
+			assert.Empty(t, resp.RequiresReplace)
+			assert.Equal(t, requests, server.Requests())
+		})
+	}
+}
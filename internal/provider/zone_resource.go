@@ -5,28 +5,31 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strconv"
 	"time"
 
 	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
 	_ resource.Resource                = &zoneResource{}
 	_ resource.ResourceWithImportState = &zoneResource{}
+	_ resource.ResourceWithModifyPlan  = &zoneResource{}
 )
 
 func NewZoneResource() resource.Resource {
@@ -45,6 +48,11 @@ type zoneResourceModel struct {
 	TTL  types.Int64  `tfsdk:"ttl"`
 	NS   types.List   `tfsdk:"ns"`
 
+	AdoptExisting            types.Bool `tfsdk:"adopt_existing"`
+	DeleteProtection         types.Bool `tfsdk:"delete_protection"`
+	PreventDeleteWithRecords types.Bool `tfsdk:"prevent_delete_with_records"`
+	AllowProtectedChange     types.Bool `tfsdk:"allow_protected_change"`
+
 	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
@@ -89,6 +97,35 @@ func (r *zoneResource) Schema(ctx context.Context, _ resource.SchemaRequest, res
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "`Default: false` Adopt an existing zone with the same name instead of failing, e.g. after the " +
+					"Terraform state got lost. The zone's TTL is updated to the configured one. Records of the zone are not adopted.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"delete_protection": schema.BoolAttribute{
+				MarkdownDescription: "`Default: false` Prevents the deletion of the zone, including its replacement, which deletes all its records as well. " +
+					"It has to be disabled and applied before the zone can be deleted. The protection is enforced by the provider, " +
+					"the zone can still be deleted using the API or the DNS Console.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"prevent_delete_with_records": schema.BoolAttribute{
+				MarkdownDescription: "`Default: false` Prevents the deletion of the zone as long as it contains records other than " +
+					"the default SOA and NS records of the zone apex.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"allow_protected_change": schema.BoolAttribute{
+				MarkdownDescription: "`Default: false` Allows to delete the zone if it contains records matching the `protected_records` " +
+					"of the provider. It has to be enabled and applied before such a zone can be deleted or replaced.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
 			"ns": schema.ListAttribute{
 				Computed:            true,
 				MarkdownDescription: "Name Servers of the zone",
@@ -146,6 +183,9 @@ func (r *zoneResource) Configure(_ context.Context, req resource.ConfigureReques
 func (r *zoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Trace(ctx, "create resource zone")
 
+	ctx, span := r.provider.startSpan(ctx, "hetznerdns_zone Create")
+	defer r.provider.endSpan(ctx, span, &resp.Diagnostics)
+
 	var plan zoneResourceModel
 
 	// Read Terraform plan into the model
@@ -168,31 +208,42 @@ func (r *zoneResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 		return
 	} else if zone != nil {
-		resp.Diagnostics.AddError("Error", fmt.Sprintf("zone %q already exists", plan.Name.ValueString()))
+		if !plan.AdoptExisting.ValueBool() {
+			resp.Diagnostics.AddError("Error", fmt.Sprintf("zone %q already exists", plan.Name.ValueString()))
+
+			return
+		}
+
+		resp.Diagnostics.Append(r.adopt(ctx, createTimeout, zone, &plan)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 
 		return
 	}
 
-	var retries int64
-
 	zoneRequest := api.CreateZoneOpts{
 		Name: plan.Name.ValueString(),
 		TTL:  plan.TTL.ValueInt64(),
 	}
 
-	err = retry.RetryContext(ctx, createTimeout, func() *retry.RetryError {
-		retries++
+	// The zone didn't exist before, so a zone with the same name was created by a failed attempt.
+	ctx = api.WithAuditInfo(ctx, api.AuditInfo{ResourceType: "hetznerdns_zone"})
 
+	err = r.provider.retryCreate(ctx, createTimeout, func(ctx context.Context) error {
 		zone, err = r.provider.apiClient.CreateZone(ctx, zoneRequest)
-		if err != nil {
-			if retries == r.provider.maxRetries {
-				return retry.NonRetryableError(err)
-			}
 
-			return retry.RetryableError(err)
+		return err
+	}, func(ctx context.Context) (bool, error) {
+		zone, err = r.provider.apiClient.GetZoneByName(ctx, zoneRequest.Name)
+		if errors.Is(err, api.ErrNotFound) {
+			return false, nil
 		}
 
-		return nil
+		return zone != nil, err
 	})
 	if err != nil {
 		resp.Diagnostics.AddError("API Error", fmt.Sprintf("creating zone: %s", err))
@@ -215,9 +266,61 @@ func (r *zoneResource) Create(ctx context.Context, req resource.CreateRequest, r
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
+// adopt takes over an existing zone for the plan and updates its TTL to the planned one.
+func (r *zoneResource) adopt(ctx context.Context, timeout time.Duration, zone *api.Zone, plan *zoneResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	detail := fmt.Sprintf("The zone %q already exists with ID %s and was adopted because `adopt_existing` is set.", zone.Name, zone.ID)
+
+	if !plan.TTL.IsNull() && plan.TTL.ValueInt64() != zone.TTL {
+		detail += fmt.Sprintf(" Its TTL was updated from %d to %d.", zone.TTL, plan.TTL.ValueInt64())
+
+		ctx = api.WithAuditInfo(ctx, api.AuditInfo{
+			ResourceType: "hetznerdns_zone",
+			ResourceID:   zone.ID,
+			ZoneID:       zone.ID,
+			Zone:         zone.Name,
+			OldValue:     strconv.FormatInt(zone.TTL, 10),
+		})
+
+		err := r.provider.retry(ctx, timeout, func(ctx context.Context) error {
+			var err error
+
+			zone, err = r.provider.apiClient.UpdateZone(ctx, api.Zone{ID: zone.ID, Name: zone.Name, TTL: plan.TTL.ValueInt64()})
+
+			return err
+		})
+		if err != nil {
+			diags.AddError("API Error", fmt.Sprintf("update TTL of adopted zone: %s", err))
+
+			return diags
+		}
+
+		r.provider.cache.Invalidate(zone.ID)
+	}
+
+	ns, nsDiags := types.ListValueFrom(ctx, types.StringType, zone.NS)
+
+	diags.Append(nsDiags...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	plan.ID = types.StringValue(zone.ID)
+	plan.NS = ns
+
+	diags.AddWarning("Existing Zone Adopted", detail+" Existing records of the zone are not managed by Terraform unless they are imported.")
+
+	return diags
+}
+
 func (r *zoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	tflog.Trace(ctx, "read resource zone")
 
+	ctx, span := r.provider.startSpan(ctx, "hetznerdns_zone Read")
+	defer r.provider.endSpan(ctx, span, &resp.Diagnostics)
+
 	var state zoneResourceModel
 
 	// Read Terraform prior state into the model
@@ -235,24 +338,14 @@ func (r *zoneResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	var (
-		err     error
-		zone    *api.Zone
-		retries int64
+		err  error
+		zone *api.Zone
 	)
 
-	err = retry.RetryContext(ctx, readTimeout, func() *retry.RetryError {
-		retries++
-
+	err = r.provider.retry(ctx, readTimeout, func(ctx context.Context) error {
 		zone, err = r.provider.apiClient.GetZone(ctx, state.ID.ValueString())
-		if err != nil {
-			if retries == r.provider.maxRetries {
-				return retry.NonRetryableError(err)
-			}
-
-			return retry.RetryableError(err)
-		}
 
-		return nil
+		return err
 	})
 	if err != nil && !errors.Is(err, api.ErrNotFound) {
 		resp.Diagnostics.AddError("API Error", fmt.Sprintf("read zone: %s", err))
@@ -279,6 +372,23 @@ func (r *zoneResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	state.ID = types.StringValue(zone.ID)
 	state.NS = ns
 
+	// The attributes are not set after an import.
+	if state.AdoptExisting.IsNull() {
+		state.AdoptExisting = types.BoolValue(false)
+	}
+
+	if state.DeleteProtection.IsNull() {
+		state.DeleteProtection = types.BoolValue(false)
+	}
+
+	if state.PreventDeleteWithRecords.IsNull() {
+		state.PreventDeleteWithRecords = types.BoolValue(false)
+	}
+
+	if state.AllowProtectedChange.IsNull() {
+		state.AllowProtectedChange = types.BoolValue(false)
+	}
+
 	// Save updated state into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -286,6 +396,9 @@ func (r *zoneResource) Read(ctx context.Context, req resource.ReadRequest, resp
 func (r *zoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Trace(ctx, "update resource zone")
 
+	ctx, span := r.provider.startSpan(ctx, "hetznerdns_zone Update")
+	defer r.provider.endSpan(ctx, span, &resp.Diagnostics)
+
 	var plan, state zoneResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -303,10 +416,7 @@ func (r *zoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 			return
 		}
 
-		var (
-			err     error
-			retries int64
-		)
+		var err error
 
 		zone := api.Zone{
 			ID:   state.ID.ValueString(),
@@ -314,19 +424,12 @@ func (r *zoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 			TTL:  plan.TTL.ValueInt64(),
 		}
 
-		err = retry.RetryContext(ctx, updateTimeout, func() *retry.RetryError {
-			retries++
-
-			_, err = r.provider.apiClient.UpdateZone(ctx, zone)
-			if err != nil {
-				if retries == r.provider.maxRetries {
-					return retry.NonRetryableError(err)
-				}
+		ctx = api.WithAuditInfo(ctx, zoneAuditInfo(state))
 
-				return retry.RetryableError(err)
-			}
+		err = r.provider.retry(ctx, updateTimeout, func(ctx context.Context) error {
+			_, err := r.provider.apiClient.UpdateZone(ctx, zone)
 
-			return nil
+			return err
 		})
 		if err != nil {
 			resp.Diagnostics.AddError("API Error", fmt.Sprintf("update zone: %s", err))
@@ -334,6 +437,8 @@ func (r *zoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 			return
 		}
 
+		r.provider.cache.Invalidate(state.ID.ValueString())
+
 		resp.Diagnostics.Append(diags...)
 
 		if resp.Diagnostics.HasError() {
@@ -348,6 +453,9 @@ func (r *zoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 func (r *zoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Trace(ctx, "deleting resource zone")
 
+	ctx, span := r.provider.startSpan(ctx, "hetznerdns_zone Delete")
+	defer r.provider.endSpan(ctx, span, &resp.Diagnostics)
+
 	var state zoneResourceModel
 
 	// Read Terraform prior state into the model
@@ -364,32 +472,199 @@ func (r *zoneResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	if state.DeleteProtection.ValueBool() {
+		resp.Diagnostics.AddError("Zone Is Delete Protected",
+			fmt.Sprintf("The zone %q can't be deleted because `delete_protection` is enabled. "+
+				"Disable it and apply the change before deleting the zone.", state.Name.ValueString()),
+		)
+
+		return
+	}
+
+	if state.PreventDeleteWithRecords.ValueBool() {
+		resp.Diagnostics.Append(r.checkNoRecords(ctx, deleteTimeout, state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !state.AllowProtectedChange.ValueBool() && len(r.provider.policy.ProtectedRecords) > 0 {
+		resp.Diagnostics.Append(r.checkNoProtectedRecords(ctx, deleteTimeout, state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(r.provider.deletes.reserve("hetznerdns_zone", state.ID.ValueString(), state.ID.ValueString())...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.provider.backupZone(ctx, deleteTimeout, state.ID.ValueString(), backupDeleteZone)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var err error
+
+	ctx = api.WithAuditInfo(ctx, zoneAuditInfo(state))
+
+	err = r.provider.retry(ctx, deleteTimeout, func(ctx context.Context) error {
+		return r.provider.apiClient.DeleteZone(ctx, state.ID.ValueString())
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("deleting zone %s: %s", state.ID, err))
+
+		return
+	}
+
+	r.provider.cache.Invalidate(state.ID.ValueString())
+}
+
+// checkNoRecords returns an error if the zone contains records other than the default SOA and NS records of the zone apex.
+func (r *zoneResource) checkNoRecords(ctx context.Context, timeout time.Duration, state zoneResourceModel) diag.Diagnostics {
 	var (
-		err     error
-		retries int64
+		diags   diag.Diagnostics
+		records *[]api.Record
 	)
 
-	err = retry.RetryContext(ctx, deleteTimeout, func() *retry.RetryError {
-		retries++
+	err := r.provider.retry(ctx, timeout, func(ctx context.Context) error {
+		var err error
 
-		err = r.provider.apiClient.DeleteZone(ctx, state.ID.ValueString())
-		if err != nil {
-			if retries == r.provider.maxRetries {
-				return retry.NonRetryableError(err)
-			}
+		records, err = r.provider.apiClient.GetRecordsByZoneID(ctx, state.ID.ValueString())
+
+		return err
+	})
+	if err != nil {
+		diags.AddError("API Error", fmt.Sprintf("reading records of zone %s: %s", state.ID, err))
+
+		return diags
+	}
+
+	var count int
 
-			return retry.RetryableError(err)
+	for _, record := range *records {
+		if record.Name != "@" || (record.Type != "SOA" && record.Type != "NS") {
+			count++
 		}
+	}
+
+	if count > 0 {
+		diags.AddError("Zone Contains Records",
+			fmt.Sprintf("The zone %q can't be deleted because it contains %d records and `prevent_delete_with_records` is enabled. "+
+				"Delete the records or disable the protection and apply the change before deleting the zone.", state.Name.ValueString(), count),
+		)
+	}
+
+	return diags
+}
+
+// checkNoProtectedRecords returns an error if the zone contains records matching the protected records of the provider.
+func (r *zoneResource) checkNoProtectedRecords(ctx context.Context, timeout time.Duration, state zoneResourceModel) diag.Diagnostics {
+	var (
+		diags   diag.Diagnostics
+		records *[]api.Record
+	)
 
-		return nil
+	err := r.provider.retry(ctx, timeout, func(ctx context.Context) error {
+		var err error
+
+		records, err = r.provider.apiClient.GetRecordsByZoneID(ctx, state.ID.ValueString())
+
+		return err
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("deleting zone %s: %s", state.ID, err))
+		diags.AddError("API Error", fmt.Sprintf("reading records of zone %s: %s", state.ID, err))
+
+		return diags
+	}
+
+	for _, record := range *records {
+		diags.Append(r.provider.checkProtectedRecord(state.Name.ValueString(), record.Name, record.Type,
+			"The zone can't be deleted because deleting it deletes the record as well. "+
+				"Set `allow_protected_change = true` on the zone and apply the change before deleting or replacing it.")...)
+	}
+
+	return diags
+}
+
+// ModifyPlan rejects all changes if the provider is read-only, deletes of delete protected zones and TTLs
+// violating the policy of the provider, and counts destroys and replacements against the delete budget.
+func (r *zoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(r.provider.checkReadOnly(req, "hetznerdns_zone")...)
+	resp.Diagnostics.Append(r.checkPlannedDeleteProtection(ctx, req)...)
+	resp.Diagnostics.Append(r.provider.planDelete(ctx, req, "hetznerdns_zone", path.Root("id"))...)
 
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() || r.provider == nil {
 		return
 	}
+
+	var ttl types.Int64
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("ttl"), &ttl)...)
+	resp.Diagnostics.Append(r.provider.checkTTLPolicy(path.Root("ttl"), ttl)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.provider.planReplace(ctx, req, resp, "hetznerdns_zone", path.Root("id"))...)
+}
+
+// checkPlannedDeleteProtection returns an error if a delete protected zone would be destroyed or replaced, so the
+// plan fails before the records of the zone are destroyed. Delete checks the protection again during apply.
+func (r *zoneResource) checkPlannedDeleteProtection(ctx context.Context, req resource.ModifyPlanRequest) diag.Diagnostics {
+	var (
+		diags            diag.Diagnostics
+		deleteProtection types.Bool
+		name             types.String
+	)
+
+	if req.State.Raw.IsNull() {
+		return diags
+	}
+
+	diags.Append(req.State.GetAttribute(ctx, path.Root("delete_protection"), &deleteProtection)...)
+	diags.Append(req.State.GetAttribute(ctx, path.Root("name"), &name)...)
+
+	if diags.HasError() || !deleteProtection.ValueBool() {
+		return diags
+	}
+
+	// The name is the only attribute whose change replaces the zone.
+	if !req.Plan.Raw.IsNull() {
+		var plannedName types.String
+
+		diags.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &plannedName)...)
+
+		if diags.HasError() || plannedName.Equal(name) {
+			return diags
+		}
+	}
+
+	diags.AddError("Zone Is Delete Protected",
+		fmt.Sprintf("The zone %q can't be deleted or replaced because `delete_protection` is enabled. "+
+			"Disable it and apply the change before deleting the zone.", name.ValueString()),
+	)
+
+	return diags
 }
 
 func (r *zoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// zoneAuditInfo describes the zone in the state for the audit log.
+func zoneAuditInfo(state zoneResourceModel) api.AuditInfo {
+	return api.AuditInfo{
+		ResourceType: "hetznerdns_zone",
+		ResourceID:   state.ID.ValueString(),
+		ZoneID:       state.ID.ValueString(),
+		Zone:         state.Name.ValueString(),
+		OldValue:     strconv.FormatInt(state.TTL.ValueInt64(), 10),
+	}
+}
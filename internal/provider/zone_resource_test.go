@@ -10,9 +10,14 @@ import (
 
 	"github.com/germanbrew/terraform-provider-hetznerdns/internal/api"
 	"github.com/germanbrew/terraform-provider-hetznerdns/internal/utils"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccZone_Resource(t *testing.T) {
@@ -197,9 +202,9 @@ func TestAccZone_StaleZone(t *testing.T) {
 					)
 
 					apiToken = utils.ConfigureStringAttribute(data.ApiToken, "HETZNER_DNS_TOKEN", "")
-					httpClient := logging.NewLoggingHTTPTransport(http.DefaultTransport)
+					httpClient := api.NewLoggingTransport(http.DefaultTransport, false)
 
-					apiClient, err = api.New("https://dns.hetzner.com", apiToken, httpClient)
+					apiClient, err = api.New(testAccAPIEndpoint, apiToken, httpClient)
 					if err != nil {
 						t.Fatalf("Error while creating API apiClient: %s", err)
 					}
@@ -224,6 +229,353 @@ func TestAccZone_StaleZone(t *testing.T) {
 	})
 }
 
+func TestAccZone_AdoptExisting(t *testing.T) {
+	aZoneName := acctest.RandString(10) + ".online"
+	aZoneTTL := 60
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create the zone outside of Terraform and adopt it
+			{
+				PreConfig: func() {
+					apiClient, err := api.New(testAccAPIEndpoint, utils.ConfigureStringAttribute(types.StringNull(), "HETZNER_DNS_TOKEN", ""), http.DefaultTransport)
+					if err != nil {
+						t.Fatalf("Error while creating API apiClient: %s", err)
+					}
+
+					_, err = apiClient.CreateZone(context.Background(), api.CreateZoneOpts{Name: aZoneName, TTL: int64(aZoneTTL * 2)})
+					if err != nil {
+						t.Fatalf("Error while creating zone: %s", err)
+					}
+				},
+				Config: fmt.Sprintf(`
+resource "hetznerdns_zone" "test" {
+    name           = %q
+    ttl            = %d
+    adopt_existing = true
+}`, aZoneName, aZoneTTL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("hetznerdns_zone.test", "id"),
+					resource.TestCheckResourceAttr("hetznerdns_zone.test", "ttl", strconv.Itoa(aZoneTTL)),
+					resource.TestCheckResourceAttr("hetznerdns_zone.test", "adopt_existing", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestZoneResourceCreateAdoptExisting(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name          string
+		adoptExisting bool
+		ttl           types.Int64
+		wantTTL       int64
+		wantErr       bool
+	}{
+		{
+			name:    "zone exists",
+			ttl:     types.Int64Value(3600),
+			wantErr: true,
+		},
+		{
+			name:          "adopt with same TTL",
+			adoptExisting: true,
+			ttl:           types.Int64Value(3600),
+			wantTTL:       3600,
+		},
+		{
+			name:          "adopt and update TTL",
+			adoptExisting: true,
+			ttl:           types.Int64Value(600),
+			wantTTL:       600,
+		},
+		{
+			name:          "adopt without TTL",
+			adoptExisting: true,
+			ttl:           types.Int64Null(),
+			wantTTL:       3600,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			provider, server, zone := newFaultTestProvider(t, 1)
+			r := &zoneResource{provider: provider}
+			schema := testResourceSchema(t, r).Schema
+
+			plan := tfsdk.Plan{Schema: schema}
+			require.False(t, plan.Set(ctx, &zoneResourceModel{
+				ID:            types.StringUnknown(),
+				Name:          types.StringValue(zone.Name),
+				TTL:           tc.ttl,
+				NS:            types.ListUnknown(types.StringType),
+				AdoptExisting: types.BoolValue(tc.adoptExisting),
+				Timeouts:      nullTimeouts(),
+			}).HasError())
+
+			resp := tfresource.CreateResponse{State: tfsdk.State{Schema: schema}}
+			r.Create(ctx, tfresource.CreateRequest{Plan: plan}, &resp)
+
+			if tc.wantErr {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "already exists")
+
+				return
+			}
+
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			require.Len(t, resp.Diagnostics.Warnings(), 1)
+			assert.Equal(t, "Existing Zone Adopted", resp.Diagnostics.Warnings()[0].Summary())
+
+			var state zoneResourceModel
+
+			require.False(t, resp.State.Get(ctx, &state).HasError())
+			assert.Equal(t, zone.ID, state.ID.ValueString())
+			assert.Equal(t, tc.ttl, state.TTL)
+			assert.Len(t, state.NS.Elements(), len(zone.NS))
+
+			adopted, ok := server.ZoneByName(zone.Name)
+			require.True(t, ok)
+			assert.Equal(t, tc.wantTTL, adopted.TTL)
+		})
+	}
+}
+
+func TestZoneResourceUpdateInvalidatesCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	provider, _, zone := newFaultTestProvider(t, 1)
+	r := &zoneResource{provider: provider}
+	schema := testResourceSchema(t, r).Schema
+
+	// Warm the cache, as the record resources of the zone do during the plan.
+	_, err := provider.cache.Zone(ctx, provider.apiClient, zone.ID)
+	require.NoError(t, err)
+
+	model := zoneResourceModel{
+		ID:                       types.StringValue(zone.ID),
+		Name:                     types.StringValue(zone.Name),
+		TTL:                      types.Int64Value(zone.TTL),
+		NS:                       types.ListNull(types.StringType),
+		AdoptExisting:            types.BoolValue(false),
+		DeleteProtection:         types.BoolValue(false),
+		PreventDeleteWithRecords: types.BoolValue(false),
+		AllowProtectedChange:     types.BoolValue(false),
+		Timeouts:                 nullTimeouts(),
+	}
+
+	state := tfsdk.State{Schema: schema}
+	require.False(t, state.Set(ctx, &model).HasError())
+
+	model.TTL = types.Int64Value(600)
+
+	plan := tfsdk.Plan{Schema: schema}
+	require.False(t, plan.Set(ctx, &model).HasError())
+
+	resp := tfresource.UpdateResponse{State: state}
+	r.Update(ctx, tfresource.UpdateRequest{State: state, Plan: plan}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	cached, err := provider.cache.Zone(ctx, provider.apiClient, zone.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(600), cached.TTL)
+}
+
+func TestZoneResourceModifyPlanDeleteProtection(t *testing.T) {
+	t.Parallel()
+
+	zone := zoneResourceModel{
+		ID:                       types.StringValue("1"),
+		Name:                     types.StringValue("example.com"),
+		TTL:                      types.Int64Value(3600),
+		NS:                       types.ListNull(types.StringType),
+		AdoptExisting:            types.BoolValue(false),
+		DeleteProtection:         types.BoolValue(true),
+		PreventDeleteWithRecords: types.BoolValue(false),
+		AllowProtectedChange:     types.BoolValue(false),
+		Timeouts:                 nullTimeouts(),
+	}
+
+	updated := zone
+	updated.TTL = types.Int64Value(60)
+
+	renamed := zone
+	renamed.Name = types.StringValue("example.org")
+
+	unprotected := zone
+	unprotected.DeleteProtection = types.BoolValue(false)
+
+	for _, tc := range []struct {
+		name    string
+		state   *zoneResourceModel
+		plan    *zoneResourceModel
+		wantErr bool
+	}{
+		{name: "destroy", state: &zone, wantErr: true},
+		{name: "replace", state: &zone, plan: &renamed, wantErr: true},
+		{name: "update", state: &zone, plan: &updated},
+		{name: "disable protection", state: &zone, plan: &unprotected},
+		{name: "destroy unprotected", state: &unprotected},
+		{name: "create", plan: &zone},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			r := &zoneResource{provider: &providerClient{}}
+			schema := testResourceSchema(t, r).Schema
+
+			state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+			if tc.state != nil {
+				require.False(t, state.Set(ctx, tc.state).HasError())
+			}
+
+			plan := tfsdk.Plan{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+			if tc.plan != nil {
+				require.False(t, plan.Set(ctx, tc.plan).HasError())
+			}
+
+			resp := tfresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, tfresource.ModifyPlanRequest{Config: tfsdk.Config{Schema: schema, Raw: plan.Raw}, State: state, Plan: plan}, &resp)
+
+			if !tc.wantErr {
+				require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+				return
+			}
+
+			require.Len(t, resp.Diagnostics.Errors(), 1, resp.Diagnostics)
+			assert.Equal(t, "Zone Is Delete Protected", resp.Diagnostics.Errors()[0].Summary())
+		})
+	}
+}
+
+func TestAccZone_DeleteProtection(t *testing.T) {
+	aZoneName := acctest.RandString(10) + ".online"
+
+	config := func(deleteProtection bool) string {
+		return fmt.Sprintf(`
+resource "hetznerdns_zone" "test" {
+    name              = %q
+    ttl               = 60
+    delete_protection = %t
+}`, aZoneName, deleteProtection)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				Check:  resource.TestCheckResourceAttr("hetznerdns_zone.test", "delete_protection", "true"),
+			},
+			{
+				Config:      config(true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("`delete_protection` is enabled"),
+			},
+			// Disable the protection, so the zone can be deleted
+			{
+				Config: config(false),
+				Check:  resource.TestCheckResourceAttr("hetznerdns_zone.test", "delete_protection", "false"),
+			},
+		},
+	})
+}
+
+func TestZoneResourceDeleteProtection(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name                     string
+		deleteProtection         bool
+		preventDeleteWithRecords bool
+		records                  []api.CreateRecordOpts
+		wantErr                  string
+	}{
+		{
+			name: "unprotected",
+		},
+		{
+			name:             "delete protection",
+			deleteProtection: true,
+			wantErr:          "Zone Is Delete Protected",
+		},
+		{
+			name:                     "only default records",
+			preventDeleteWithRecords: true,
+		},
+		{
+			name:                     "records",
+			preventDeleteWithRecords: true,
+			records:                  []api.CreateRecordOpts{{Type: "A", Name: "www", Value: "192.0.2.1"}},
+			wantErr:                  "Zone Contains Records",
+		},
+		{
+			name:                     "NS record of a sub domain",
+			preventDeleteWithRecords: true,
+			records:                  []api.CreateRecordOpts{{Type: "NS", Name: "sub", Value: "ns1.example.net."}},
+			wantErr:                  "Zone Contains Records",
+		},
+		{
+			name:    "records without protection",
+			records: []api.CreateRecordOpts{{Type: "A", Name: "www", Value: "192.0.2.1"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			provider, server, zone := newFaultTestProvider(t, 1)
+			r := &zoneResource{provider: provider}
+			schema := testResourceSchema(t, r).Schema
+
+			for _, record := range tc.records {
+				record.ZoneID = zone.ID
+
+				_, err := provider.apiClient.CreateRecord(ctx, record)
+				require.NoError(t, err)
+			}
+
+			state := tfsdk.State{Schema: schema}
+			require.False(t, state.Set(ctx, &zoneResourceModel{
+				ID:                       types.StringValue(zone.ID),
+				Name:                     types.StringValue(zone.Name),
+				TTL:                      types.Int64Value(zone.TTL),
+				NS:                       types.ListNull(types.StringType),
+				AdoptExisting:            types.BoolValue(false),
+				DeleteProtection:         types.BoolValue(tc.deleteProtection),
+				PreventDeleteWithRecords: types.BoolValue(tc.preventDeleteWithRecords),
+				Timeouts:                 nullTimeouts(),
+			}).HasError())
+
+			resp := tfresource.DeleteResponse{State: state}
+			r.Delete(ctx, tfresource.DeleteRequest{State: state}, &resp)
+
+			_, exists := server.ZoneByName(zone.Name)
+
+			if tc.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tc.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				assert.True(t, exists)
+
+				return
+			}
+
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.False(t, exists)
+		})
+	}
+}
+
 func testAccZoneResourceConfig(resourceName string, name string, ttl int) string {
 	return fmt.Sprintf(`
 resource "hetznerdns_zone" "%[1]s" {
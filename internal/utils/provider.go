@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -53,3 +54,25 @@ func ConfigureBoolAttribute(attr types.Bool, envVar string, defaultValue bool) (
 
 	return defaultValue, nil
 }
+
+func ConfigureDurationAttribute(attr types.String, envVar string, defaultValue time.Duration) (time.Duration, error) {
+	if !attr.IsNull() {
+		d, err := time.ParseDuration(attr.ValueString())
+		if err != nil {
+			return 0, fmt.Errorf("error parsing duration: %w", err)
+		}
+
+		return d, nil
+	}
+
+	if v, ok := os.LookupEnv(envVar); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("error parsing %s: %w", envVar, err)
+		}
+
+		return d, nil
+	}
+
+	return defaultValue, nil
+}
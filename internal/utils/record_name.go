@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrInvalidRecordName     = errors.New("invalid record name")
+	ErrRecordNameOutsideZone = errors.New("record name is outside of zone")
+)
+
+// ApexRecordName is the name of records at the apex of a zone.
+const ApexRecordName = "@"
+
+// CheckRecordName checks if the given string is a syntactically valid record name.
+// Valid names are the apex forms "@" and "", names relative to the zone and fully
+// qualified domain names with a trailing dot. Labels may contain any printable character
+// except whitespace, e.g. "0/26" for classless reverse delegation (RFC 2317).
+func CheckRecordName(name string) error {
+	if name == "" || name == ApexRecordName {
+		return nil
+	}
+
+	trimmed := strings.TrimSuffix(name, ".")
+	if trimmed == "" || len(trimmed) > 253 {
+		return fmt.Errorf("%w: %q", ErrInvalidRecordName, name)
+	}
+
+	for _, label := range strings.Split(trimmed, ".") {
+		if label == "" || len(label) > 63 {
+			return fmt.Errorf("%w: %q contains an empty or too long label", ErrInvalidRecordName, name)
+		}
+
+		for _, c := range label {
+			if !isLabelChar(c) {
+				return fmt.Errorf("%w: %q contains the invalid character %q", ErrInvalidRecordName, name, c)
+			}
+		}
+	}
+
+	return nil
+}
+
+// NormalizeRecordName converts a record name to the form the API expects for the given zone.
+// Apex forms become "@", names under the zone become relative names. Fully qualified names
+// with a trailing dot that are outside the zone are rejected.
+func NormalizeRecordName(name, zone string) (string, error) {
+	if name == "" || name == ApexRecordName {
+		return ApexRecordName, nil
+	}
+
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	fqdn := strings.HasSuffix(name, ".")
+	relative := strings.TrimSuffix(name, ".")
+	lower := strings.ToLower(relative)
+
+	switch {
+	case lower == zone:
+		return ApexRecordName, nil
+	case strings.HasSuffix(lower, "."+zone):
+		return relative[:len(relative)-len(zone)-1], nil
+	case fqdn:
+		return "", fmt.Errorf("%w: %q isn't part of zone %q", ErrRecordNameOutsideZone, name, zone)
+	}
+
+	return relative, nil
+}
+
+// CheckRecordNameInAnyZone checks if a record name can belong to any zone. Zones are at least second level
+// domains, so fully qualified names of a single label, e.g. "localhost.", are rejected.
+func CheckRecordNameInAnyZone(name string) error {
+	if !strings.HasSuffix(name, ".") || name == "." {
+		return nil
+	}
+
+	if !strings.Contains(strings.TrimSuffix(name, "."), ".") {
+		return fmt.Errorf("%w: %q is a top level domain and can't be part of any zone", ErrRecordNameOutsideZone, name)
+	}
+
+	return nil
+}
+
+// CanonicalRecordName returns a zone independent canonical form of a record name.
+// Two names with the same canonical form refer to the same record in any zone.
+func CanonicalRecordName(name string) string {
+	if name == "" {
+		return ApexRecordName
+	}
+
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// RecordFQDN returns the fully qualified domain name of a normalized record name in the given zone.
+func RecordFQDN(name, zone string) string {
+	zone = strings.TrimSuffix(zone, ".")
+
+	if name == ApexRecordName {
+		return zone + "."
+	}
+
+	return name + "." + zone + "."
+}
+
+func isLabelChar(c rune) bool {
+	return unicode.IsPrint(c) && !unicode.IsSpace(c)
+}
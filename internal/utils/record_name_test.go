@@ -0,0 +1,113 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/germanbrew/terraform-provider-hetznerdns/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRecordName(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		input   string
+		isValid bool
+	}{
+		{name: "empty", input: "", isValid: true},
+		{name: "apex", input: "@", isValid: true},
+		{name: "relative", input: "www", isValid: true},
+		{name: "relative with sub domain", input: "a.b", isValid: true},
+		{name: "fqdn", input: "www.example.com.", isValid: true},
+		{name: "wildcard", input: "*.dev", isValid: true},
+		{name: "service", input: "_sip._tcp", isValid: true},
+		{name: "asterisk not first", input: "dev.*", isValid: true},
+		{name: "classless reverse delegation", input: "0/26", isValid: true},
+		{name: "classless reverse delegation with hyphen", input: "0-26.2.0.192.in-addr.arpa.", isValid: true},
+		{name: "only dot", input: ".", isValid: false},
+		{name: "empty label", input: "a..b", isValid: false},
+		{name: "space", input: "w w w", isValid: false},
+		{name: "tab", input: "www\t", isValid: false},
+		{name: "label too long", input: strings.Repeat("a", 64), isValid: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := utils.CheckRecordName(tc.input)
+			if tc.isValid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, utils.ErrInvalidRecordName)
+			}
+		})
+	}
+}
+
+func TestNormalizeRecordName(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		input  string
+		zone   string
+		output string
+		err    error
+	}{
+		{name: "empty", input: "", zone: "example.com", output: "@"},
+		{name: "apex", input: "@", zone: "example.com", output: "@"},
+		{name: "zone name", input: "example.com", zone: "example.com", output: "@"},
+		{name: "zone fqdn", input: "example.com.", zone: "example.com", output: "@"},
+		{name: "relative", input: "www", zone: "example.com", output: "www"},
+		{name: "fqdn", input: "www.example.com.", zone: "example.com", output: "www"},
+		{name: "fqdn without trailing dot", input: "www.example.com", zone: "example.com", output: "www"},
+		{name: "fqdn with different case", input: "WWW.Example.COM.", zone: "example.com", output: "WWW"},
+		{name: "sub domain", input: "a.b.example.com.", zone: "example.com", output: "a.b"},
+		{name: "relative outside zone", input: "www.example.org", zone: "example.com", output: "www.example.org"},
+		{name: "fqdn outside zone", input: "www.example.org.", zone: "example.com", err: utils.ErrRecordNameOutsideZone},
+		{name: "fqdn of zone suffix", input: "badexample.com.", zone: "example.com", err: utils.ErrRecordNameOutsideZone},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := utils.NormalizeRecordName(tc.input, tc.zone)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.output, output)
+		})
+	}
+}
+
+func TestCheckRecordNameInAnyZone(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, utils.CheckRecordNameInAnyZone("@"))
+	require.NoError(t, utils.CheckRecordNameInAnyZone("www"))
+	require.NoError(t, utils.CheckRecordNameInAnyZone("example.com."))
+	require.NoError(t, utils.CheckRecordNameInAnyZone("www.example.com."))
+	require.ErrorIs(t, utils.CheckRecordNameInAnyZone("localhost."), utils.ErrRecordNameOutsideZone)
+	require.ErrorIs(t, utils.CheckRecordNameInAnyZone("com."), utils.ErrRecordNameOutsideZone)
+}
+
+func TestCanonicalRecordName(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "@", utils.CanonicalRecordName(""))
+	require.Equal(t, "@", utils.CanonicalRecordName("@"))
+	require.Equal(t, "www.example.com", utils.CanonicalRecordName("WWW.example.com."))
+	require.Equal(t, "www", utils.CanonicalRecordName("www"))
+}
+
+func TestRecordFQDN(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "example.com.", utils.RecordFQDN("@", "example.com"))
+	require.Equal(t, "www.example.com.", utils.RecordFQDN("www", "example.com"))
+	require.Equal(t, "www.example.com.", utils.RecordFQDN("www", "example.com."))
+}
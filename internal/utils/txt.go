@@ -3,71 +3,162 @@ package utils
 import (
 	"fmt"
 	"strings"
+	"unicode/utf8"
 )
 
-// PlainToTXTRecordValue Converts a plain string to a TXT record value.
-// if the value in a TXT record is longer than 255 bytes, it needs to be split into multiple parts.
-// each part needs to be enclosed in double quotes and separated by a space.
+// maxCharacterStringLength is the maximum length of a single character-string in bytes.
+const maxCharacterStringLength = 255
+
+// EncodeTXTRecordValue encodes a plain string as a TXT record value made up of RFC 1035 character-strings.
+// Every character-string holds at most 255 bytes, is enclosed in double quotes and separated from the next one by a space.
+// Double quotes and backslashes are escaped with a backslash, control characters and invalid UTF-8 bytes as \DDD.
+// Character-strings are never split in the middle of a UTF-8 encoded character.
 //
+// https://datatracker.ietf.org/doc/html/rfc1035#section-5.1
 // https://datatracker.ietf.org/doc/html/rfc4408#section-3.1.3
-func PlainToTXTRecordValue(value string) string {
-	if len(value) < 255 {
-		return value
-	}
+func EncodeTXTRecordValue(value string) string {
+	var (
+		record strings.Builder
+		length int
+	)
 
-	if isChunkedTXTRecordValue(value) {
-		return value
-	}
+	record.WriteByte('"')
+
+	for i := 0; i < len(value); {
+		r, size := utf8.DecodeRuneInString(value[i:])
+
+		if length+size > maxCharacterStringLength {
+			record.WriteString(`" "`)
+
+			length = 0
+		}
 
-	record := strings.Builder{}
+		switch {
+		case r == utf8.RuneError && size == 1:
+			fmt.Fprintf(&record, `\%03d`, value[i])
+		case r == '"' || r == '\\':
+			record.WriteByte('\\')
+			record.WriteRune(r)
+		case r < ' ' || r == 0x7f:
+			fmt.Fprintf(&record, `\%03d`, r)
+		default:
+			record.WriteString(value[i : i+size])
+		}
 
-	for _, chunk := range chunkSlice(value, 255) {
-		record.WriteString(fmt.Sprintf("%q ", chunk))
+		length += size
+		i += size
 	}
 
+	record.WriteByte('"')
+
 	return record.String()
 }
 
-// TXTRecordToPlainValue converts a TXT record value to a plain string.
-// It reverses the operation of PlainToTXTRecordValue.
-func TXTRecordToPlainValue(value string) string {
-	if !isChunkedTXTRecordValue(value) {
+// IsTXTCharacterStrings reports whether a TXT record value is entirely made up of quoted RFC 1035 character-strings,
+// like the chunked DKIM keys which earlier versions of this provider sent to the API unchanged.
+func IsTXTCharacterStrings(value string) bool {
+	_, ok := decodeCharacterStrings(value)
+
+	return ok
+}
+
+// DecodeTXTRecordValue decodes a TXT record value made up of quoted RFC 1035 character-strings into a plain string.
+// It reverses the operation of EncodeTXTRecordValue. Values which are not entirely made up of quoted character-strings,
+// like values of records created outside of Terraform or short values written by earlier versions of this provider,
+// are returned unchanged.
+func DecodeTXTRecordValue(value string) string {
+	decoded, ok := decodeCharacterStrings(value)
+	if !ok {
 		return value
 	}
 
-	// remove the last space
-	value = strings.TrimSuffix(value, " ")
+	return decoded
+}
+
+func decodeCharacterStrings(value string) (string, bool) {
+	var (
+		plain strings.Builder
+		found bool
+	)
+
+	for i := 0; i < len(value); {
+		switch value[i] {
+		case ' ', '\t':
+			i++
+
+			continue
+		case '"':
+		default:
+			return "", false
+		}
+
+		end, ok := decodeQuotedString(value, i+1, &plain)
+		if !ok {
+			return "", false
+		}
 
-	// remove the first and last double quote
-	value = strings.Trim(value, `"`)
+		// A character-string must be followed by whitespace or the end of the value.
+		if end < len(value) && value[end] != ' ' && value[end] != '\t' {
+			return "", false
+		}
 
-	// remove the inner double quotes
-	value = strings.Join(strings.Split(value, `" "`), "")
+		found = true
+		i = end
+	}
 
-	// unescape the escaped double quotes
-	return strings.ReplaceAll(value, `\"`, `"`)
+	return plain.String(), found
 }
 
-// isChunkedTXTRecordValue checks if the value is a chunked TXT record value. A chunked TXT record value is a string
-// that starts with a double quote and ends with a double quote and optionally a space.
-func isChunkedTXTRecordValue(value string) bool {
-	return strings.HasPrefix(value, `"`) && (strings.HasSuffix(value, `" `) || strings.HasSuffix(value, `"`))
+// decodeQuotedString decodes the quoted string starting at the given offset after the opening quote and
+// returns the offset after the closing quote.
+func decodeQuotedString(value string, offset int, plain *strings.Builder) (int, bool) {
+	for i := offset; i < len(value); i++ {
+		switch value[i] {
+		case '"':
+			return i + 1, true
+		case '\\':
+			if i+1 >= len(value) {
+				return 0, false
+			}
+
+			if b, ok := decodeDecimalEscape(value[i+1:]); ok {
+				plain.WriteByte(b)
+
+				i += 3
+
+				continue
+			}
+
+			plain.WriteByte(value[i+1])
+
+			i++
+		default:
+			plain.WriteByte(value[i])
+		}
+	}
+
+	return 0, false
 }
 
-func chunkSlice(slice string, chunkSize int) []string {
-	var chunks []string
+// decodeDecimalEscape decodes the three digits of a \DDD escape sequence.
+func decodeDecimalEscape(value string) (byte, bool) {
+	if len(value) < 3 {
+		return 0, false
+	}
 
-	for i := 0; i < len(slice); i += chunkSize {
-		end := i + chunkSize
+	var b int
 
-		// necessary check to avoid slicing beyond
-		// slice capacity
-		if end > len(slice) {
-			end = len(slice)
+	for _, c := range []byte(value[:3]) {
+		if c < '0' || c > '9' {
+			return 0, false
 		}
 
-		chunks = append(chunks, slice[i:end])
+		b = b*10 + int(c-'0')
+	}
+
+	if b > 255 {
+		return 0, false
 	}
 
-	return chunks
+	return byte(b), true
 }
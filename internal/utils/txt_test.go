@@ -4,12 +4,13 @@ package utils_test
 import (
 	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/germanbrew/terraform-provider-hetznerdns/internal/utils"
 	"github.com/stretchr/testify/require"
 )
 
-func TestPlainToTXTRecordValue(t *testing.T) {
+func TestEncodeTXTRecordValue(t *testing.T) {
 	t.Parallel()
 
 	for _, tc := range []struct {
@@ -20,48 +21,97 @@ func TestPlainToTXTRecordValue(t *testing.T) {
 		{
 			name:   "empty",
 			input:  "",
-			output: "",
+			output: `""`,
 		},
 		{
 			name:   "small string",
 			input:  "test",
-			output: "test",
+			output: `"test"`,
 		},
 		{
 			name:   "small string with quotes",
 			input:  `t"e"s"t`,
-			output: `t"e"s"t`,
+			output: `"t\"e\"s\"t"`,
 		},
 		{
 			name:   "small string with spaces",
 			input:  `v=STSv1; id=20230523103000Z`,
-			output: `v=STSv1; id=20230523103000Z`,
+			output: `"v=STSv1; id=20230523103000Z"`,
+		},
+		{
+			name:   "quoted string",
+			input:  `"quoted"`,
+			output: `"\"quoted\""`,
+		},
+		{
+			name:   "backslash",
+			input:  `a\b`,
+			output: `"a\\b"`,
+		},
+		{
+			name:   "control characters",
+			input:  "a\tb\nc\x7f",
+			output: `"a\009b\010c\127"`,
+		},
+		{
+			name:   "invalid UTF-8",
+			input:  "a\xffb",
+			output: `"a\255b"`,
+		},
+		{
+			name:   "UTF-8",
+			input:  "grüße",
+			output: `"grüße"`,
 		},
 		{
 			name:   "large string",
 			input:  strings.Repeat("test", 100),
-			output: `"testtesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttes" "ttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttest" `,
+			output: `"testtesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttes" "ttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttest"`,
 		},
 		{
-			name:   "large string with quotes",
-			input:  strings.Repeat(`t"e"s"t`, 100),
-			output: `"t\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e" "\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"" "tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"t" `,
+			name:   "large string with escaped quotes",
+			input:  strings.Repeat(`"`, 256),
+			output: `"` + strings.Repeat(`\"`, 255) + `" "\""`,
 		},
 		{
-			name:   "large string with spaces",
-			input:  strings.Repeat(`t e s t`, 100),
-			output: `"t e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e" " s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s " "tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s t" `,
+			name:   "large string with multi-byte characters",
+			input:  strings.Repeat("a", 254) + "ü",
+			output: `"` + strings.Repeat("a", 254) + `" "ü"`,
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			require.Equal(t, tc.output, utils.PlainToTXTRecordValue(tc.input))
+			require.Equal(t, tc.output, utils.EncodeTXTRecordValue(tc.input))
 		})
 	}
 }
 
-func TestTXTRecordToPlainValue(t *testing.T) {
+func TestIsTXTCharacterStrings(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "plain string", input: "test", want: false},
+		{name: "quoted string", input: `"quoted"`, want: true},
+		{name: "chunked string", input: `"abc" "def"`, want: true},
+		{name: "chunked string with trailing space", input: `"abc" "def" `, want: true},
+		{name: "escaped quotes", input: `"a\"b"`, want: true},
+		{name: "partially quoted string", input: `"abc" def`, want: false},
+		{name: "unterminated quote", input: `"abc`, want: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.want, utils.IsTXTCharacterStrings(tc.input))
+		})
+	}
+}
+
+func TestDecodeTXTRecordValue(t *testing.T) {
 	t.Parallel()
 
 	for _, tc := range []struct {
@@ -75,45 +125,60 @@ func TestTXTRecordToPlainValue(t *testing.T) {
 			output: "",
 		},
 		{
-			name:   "small string",
+			name:   "empty quoted string",
+			input:  `""`,
+			output: "",
+		},
+		{
+			name:   "unquoted string",
 			input:  "test",
 			output: "test",
 		},
 		{
-			name:   "small string with quotes",
+			name:   "unquoted string with quotes",
 			input:  `t"e"s"t`,
 			output: `t"e"s"t`,
 		},
 		{
-			name:   "small string with spaces",
+			name:   "unquoted string with spaces",
 			input:  `v=STSv1; id=20230523103000Z`,
 			output: `v=STSv1; id=20230523103000Z`,
 		},
 		{
-			name:   "large string",
-			input:  `"testtesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttes" "ttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttest" `,
-			output: strings.Repeat("test", 100),
+			name:   "unquoted string starting and ending with quotes",
+			input:  `"a" b "c"`,
+			output: `"a" b "c"`,
+		},
+		{
+			name:   "unterminated quoted string",
+			input:  `"abc`,
+			output: `"abc`,
 		},
 		{
-			name:   "large string with quotes",
-			input:  `"t\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e" "\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"" "tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"tt\"e\"s\"t" `,
-			output: strings.Repeat(`t"e"s"t`, 100),
+			name:   "quoted string",
+			input:  `"test"`,
+			output: "test",
 		},
 		{
-			name:   "large string with spaces",
-			input:  `"t e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s t" "t e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s tt e s t" `,
-			output: strings.Repeat(`t e s t`, 100),
+			name:   "escapes",
+			input:  `"a\"b\\c\009d\255"`,
+			output: "a\"b\\c\td\xff",
+		},
+		{
+			name:   "large string",
+			input:  `"testtesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttes" "ttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttest" `,
+			output: strings.Repeat("test", 100),
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			require.Equal(t, tc.output, utils.TXTRecordToPlainValue(tc.input))
+			require.Equal(t, tc.output, utils.DecodeTXTRecordValue(tc.input))
 		})
 	}
 }
 
-func TestPlainToTXTRecordToPlainValue(t *testing.T) {
+func TestEncodeDecodeTXTRecordValue(t *testing.T) {
 	t.Parallel()
 
 	for _, tc := range []struct {
@@ -136,6 +201,14 @@ func TestPlainToTXTRecordToPlainValue(t *testing.T) {
 			name:  "small string with spaces",
 			value: `v=STSv1; id=20230523103000Z`,
 		},
+		{
+			name:  "quoted string",
+			value: `"quoted"`,
+		},
+		{
+			name:  "chunked string",
+			value: `"a" "b"`,
+		},
 		{
 			name:  "large string",
 			value: strings.Repeat("test", 100),
@@ -148,11 +221,28 @@ func TestPlainToTXTRecordToPlainValue(t *testing.T) {
 			name:  "large string with spaces",
 			value: strings.Repeat("t e s t", 100),
 		},
+		{
+			name:  "large string with multi-byte characters",
+			value: strings.Repeat("grüße ", 100),
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			require.Equal(t, tc.value, utils.TXTRecordToPlainValue(utils.TXTRecordToPlainValue(tc.value)))
+			require.Equal(t, tc.value, utils.DecodeTXTRecordValue(utils.EncodeTXTRecordValue(tc.value)))
 		})
 	}
 }
+
+func FuzzEncodeDecodeTXTRecordValue(f *testing.F) {
+	for _, seed := range []string{"", "test", `t"e"s"t`, `"quoted"`, `a\b`, "a\x00\xff", "grüße", strings.Repeat("ü", 200)} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, value string) {
+		encoded := utils.EncodeTXTRecordValue(value)
+
+		require.Equal(t, value, utils.DecodeTXTRecordValue(encoded))
+		require.True(t, utf8.ValidString(encoded), "encoded value must be valid UTF-8")
+	})
+}